@@ -0,0 +1,976 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/go-openapi/spec"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// encodeOptions controls how a JSON document is converted into DynamoDB JSON.
+type encodeOptions struct {
+	// Spec is an optional JSON Schema the document is validated against.
+	Spec string
+	// TypeHints maps JSON Pointer patterns to the DynamoDB type the matching
+	// values are converted to, overriding the type implied by the JSON.
+	TypeHints map[string]string
+	// SchemaTypes derives DynamoDB types from Spec, see schemaTypeHints.
+	SchemaTypes bool
+	// EpochDateTimes encodes format: date-time strings as N epoch seconds
+	// when SchemaTypes is set.
+	EpochDateTimes bool
+	// ApplyDefaults fills in missing properties from the defaults of Spec,
+	// see applySchema.
+	ApplyDefaults bool
+	// CoerceTypes converts numeric and boolean strings to the types Spec
+	// declares, see applySchema.
+	CoerceTypes bool
+	// SchemaDialect is the JSON Schema draft of a Spec without $schema.
+	SchemaDialect string
+	// LimitViolations is limitViolationsError (the default) to fail on items
+	// DynamoDB would reject, or limitViolationsWarn to only warn about them.
+	LimitViolations string
+	// ValidationMode is validationModeError (the default) to fail on spec
+	// violations, validationModeWarn to only warn about them, or
+	// validationModeOff to ignore them.
+	ValidationMode string
+	// ValidationSeverity overrides ValidationMode for the violations of a
+	// schema keyword or at a JSON Pointer pattern.
+	ValidationSeverity map[string]string
+	// SchemaRef makes Spec an OpenAPI 3.x document, in JSON or YAML, and
+	// names the component schema items are validated against.
+	SchemaRef string
+	// OpenAPIDirection is openAPIDirectionRequest (the default) or
+	// openAPIDirectionResponse.
+	OpenAPIDirection string
+	// SpecFile is read as Spec, resolving references relative to it.
+	SpecFile string
+	// SpecDir is the directory a relative SpecFile, or the references of
+	// Spec, resolve against.
+	SpecDir string
+	// Specs caches the documents of SpecFile and references to other
+	// documents. A nil cache reads them for this encoder only.
+	Specs *specCache
+}
+
+// encodeJSON converts a JSON document into DynamoDB JSON. It is shared by the
+// json2dynamodb data source and the encode function, so both produce identical
+// output. Diagnostics are reported against the "json" and "spec" attributes.
+func encodeJSON(input string, opts encodeOptions) (string, diag.Diagnostics) {
+	avs, diags := encodeItemJSON(input, opts)
+	if diags.HasError() {
+		return "", diags
+	}
+
+	result, itemDiags := serializeItem(avs)
+	diags.Append(itemDiags...)
+	return result, diags
+}
+
+// encodeItemJSON parses a JSON document and converts it into DynamoDB
+// attribute values.
+func encodeItemJSON(input string, opts encodeOptions) (map[string]types.AttributeValue, diag.Diagnostics) {
+	encoder, diags := newItemEncoder(opts)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	avs, itemDiags := encoder.encodeJSON(input)
+	diags.Append(itemDiags...)
+	return avs, diags
+}
+
+// itemEncoder validates decoded JSON items and converts them into DynamoDB
+// attribute values. The spec and type hints are parsed once, so one encoder
+// can convert many items.
+type itemEncoder struct {
+	opts encodeOptions
+	// validator validates items against the spec.
+	validator specValidator
+	severity  *validationSeverity
+	// schema is the spec as read for schema_types, apply_defaults and
+	// coerce_types.
+	schema    *spec.Schema
+	typeHints []typeHint
+	// appliedChanges describes the changes apply_defaults and coerce_types
+	// made to the items encoded so far.
+	appliedChanges []string
+}
+
+// rewritesItems reports whether items are rewritten by the spec before they
+// are validated.
+func (o encodeOptions) rewritesItems() bool {
+	return o.ApplyDefaults || o.CoerceTypes
+}
+
+// specValidator validates decoded JSON items against a spec.
+type specValidator interface {
+	// Validate returns every way the item breaks the spec.
+	Validate(v interface{}) []specViolation
+	// Draft names the dialect the spec is validated with.
+	Draft() string
+}
+
+// newItemEncoder parses the encoding options. Diagnostics are reported against
+// the "spec", "spec_file", "schema_ref", "openapi_direction",
+// "schema_dialect", "validation_mode", "validation_severity", "type_hints",
+// "schema_types", "epoch_date_times", "apply_defaults", "coerce_types" and
+// "limit_violations" attributes.
+func newItemEncoder(opts encodeOptions) (*itemEncoder, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	e := &itemEncoder{opts: opts}
+
+	switch opts.LimitViolations {
+	case "":
+		e.opts.LimitViolations = limitViolationsError
+	case limitViolationsError, limitViolationsWarn:
+	default:
+		diags.AddAttributeError(
+			path.Root("limit_violations"),
+			"Invalid Limit Violations",
+			fmt.Sprintf("Expected one of %q or %q, got: %q.", limitViolationsError, limitViolationsWarn, opts.LimitViolations),
+		)
+		return nil, diags
+	}
+
+	if opts.SchemaDialect != "" && !slices.Contains(schemaDialectNames(), opts.SchemaDialect) {
+		diags.AddAttributeError(
+			path.Root("schema_dialect"),
+			"Invalid Schema Dialect",
+			fmt.Sprintf("Expected one of %s, got: %q.", strings.Join(schemaDialectNames(), ", "), opts.SchemaDialect),
+		)
+		return nil, diags
+	}
+
+	switch opts.OpenAPIDirection {
+	case "", openAPIDirectionRequest, openAPIDirectionResponse:
+	default:
+		diags.AddAttributeError(
+			path.Root("openapi_direction"),
+			"Invalid OpenAPI Direction",
+			fmt.Sprintf("Expected one of %q or %q, got: %q.", openAPIDirectionRequest, openAPIDirectionResponse, opts.OpenAPIDirection),
+		)
+		return nil, diags
+	}
+
+	switch opts.ValidationMode {
+	case "", validationModeError, validationModeWarn, validationModeOff:
+	default:
+		diags.AddAttributeError(
+			path.Root("validation_mode"),
+			"Invalid Validation Mode",
+			fmt.Sprintf("Expected one of %q, %q or %q, got: %q.", validationModeError, validationModeWarn, validationModeOff, opts.ValidationMode),
+		)
+		return nil, diags
+	}
+
+	severity, err := parseValidationSeverity(opts.ValidationMode, opts.ValidationSeverity)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("validation_severity"),
+			"Invalid Validation Severity",
+			fmt.Sprintf("The provider received an invalid severity override.\n\nError: %s", err),
+		)
+		return nil, diags
+	}
+	e.severity = severity
+
+	typeHints, err := parseTypeHints(opts.TypeHints)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("type_hints"),
+			"Invalid Type Hints",
+			fmt.Sprintf("The provider received an invalid type hint.\n\nError: %s", err),
+		)
+		return nil, diags
+	}
+	e.typeHints = typeHints
+
+	if opts.EpochDateTimes && !opts.SchemaTypes {
+		diags.AddAttributeError(
+			path.Root("epoch_date_times"),
+			"Missing Schema Types",
+			"Encoding date-time strings as epoch seconds requires schema_types to be enabled.",
+		)
+		return nil, diags
+	}
+
+	specPath := path.Root("spec")
+	if opts.SpecFile != "" {
+		specPath = path.Root("spec_file")
+		if opts.Spec != "" {
+			diags.AddAttributeError(
+				specPath,
+				"Conflicting JSON Spec",
+				"Only one of spec and spec_file can be set.",
+			)
+			return nil, diags
+		}
+	}
+
+	if opts.Spec == "" && opts.SpecFile == "" {
+		if opts.SchemaTypes {
+			diags.AddAttributeError(
+				path.Root("schema_types"),
+				"Missing JSON Spec",
+				"Deriving DynamoDB types from the schema requires spec or spec_file to be set.",
+			)
+			return nil, diags
+		}
+		if opts.rewritesItems() {
+			attr := "apply_defaults"
+			if !opts.ApplyDefaults {
+				attr = "coerce_types"
+			}
+			diags.AddAttributeError(
+				path.Root(attr),
+				"Missing JSON Spec",
+				"Applying defaults or coercing types from the schema requires spec or spec_file to be set.",
+			)
+			return nil, diags
+		}
+		if opts.SchemaRef != "" {
+			diags.AddAttributeError(
+				path.Root("schema_ref"),
+				"Missing JSON Spec",
+				"A schema_ref requires spec or spec_file to be set to an OpenAPI document.",
+			)
+			return nil, diags
+		}
+		return e, diags
+	}
+
+	src, err := newSpecSource(opts)
+	if err != nil {
+		diags.AddAttributeError(
+			specPath,
+			"JSON Spec Handling Failed",
+			fmt.Sprintf("The provider received an unexpected error while attempting to read the spec file.\n\nError: %s", err),
+		)
+		return nil, diags
+	}
+
+	if err := src.checkRefCycles(); err != nil {
+		diags.AddAttributeError(
+			specPath,
+			"JSON Spec Handling Failed",
+			fmt.Sprintf("The provider received an unexpected error while attempting to resolve references in the spec.\n\nError: %s", err),
+		)
+		return nil, diags
+	}
+
+	if opts.SchemaRef != "" {
+		return e.withOpenAPIDocument(src, specPath, diags)
+	}
+
+	validator, err := compileJSONSchema(src, opts.SchemaDialect)
+	if err != nil {
+		diags.AddAttributeError(
+			specPath,
+			"JSON Spec Handling Failed",
+			fmt.Sprintf("The provider received an unexpected error while attempting to compile the JSON Schema.\n\nError: %s", err),
+		)
+		return nil, diags
+	}
+	e.validator = jsonSchemaValidator{validator}
+
+	if !opts.SchemaTypes && !opts.rewritesItems() {
+		return e, diags
+	}
+
+	raw, err := src.readJSON(src.url)
+	if err == nil {
+		e.schema = new(spec.Schema)
+		err = e.schema.UnmarshalJSON(raw)
+	}
+	if err != nil {
+		diags.AddAttributeError(
+			specPath,
+			"JSON Spec Handling Failed",
+			fmt.Sprintf("The provider received an unexpected error while attempting to build the OpenAPI Specification.\n\nError: %s", err),
+		)
+		return nil, diags
+	}
+
+	if src.external {
+		err = spec.ExpandSchemaWithBasePath(e.schema, nil, &spec.ExpandOptions{
+			RelativeBase: src.url.Path,
+			PathLoader:   src.pathLoader(nil),
+		})
+	} else {
+		err = spec.ExpandSchema(e.schema, nil, nil)
+	}
+	if err != nil {
+		diags.AddAttributeError(
+			specPath,
+			"JSON Spec Handling Failed",
+			fmt.Sprintf("The provider received an unexpected error while attempting to resolve references in the OpenAPI Specification.\n\nError: %s", err),
+		)
+		return nil, diags
+	}
+	return e, diags
+}
+
+// withOpenAPIDocument reads the spec as an OpenAPI document and validates
+// items against the component schema_ref points to.
+func (e *itemEncoder) withOpenAPIDocument(src *specSource, specPath path.Path, diags diag.Diagnostics) (*itemEncoder, diag.Diagnostics) {
+	doc, err := loadOpenAPIDocument(src)
+	if err != nil {
+		diags.AddAttributeError(
+			specPath,
+			"JSON Spec Handling Failed",
+			fmt.Sprintf("The provider received an unexpected error while attempting to load the OpenAPI document.\n\nError: %s", err),
+		)
+		return nil, diags
+	}
+
+	validator, err := doc.validator(e.opts.SchemaRef, e.opts.OpenAPIDirection)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("schema_ref"),
+			"Invalid Schema Reference",
+			fmt.Sprintf("The provider could not find the schema in the OpenAPI document.\n\nError: %s", err),
+		)
+		return nil, diags
+	}
+	e.validator = validator
+
+	if !e.opts.SchemaTypes && !e.opts.rewritesItems() {
+		return e, diags
+	}
+
+	if e.schema, err = doc.typeSchema(e.opts.SchemaRef); err != nil {
+		diags.AddAttributeError(
+			specPath,
+			"JSON Spec Handling Failed",
+			fmt.Sprintf("The provider received an unexpected error while attempting to resolve references in the OpenAPI document.\n\nError: %s", err),
+		)
+		return nil, diags
+	}
+	return e, diags
+}
+
+// specDraft returns the JSON Schema draft, or OpenAPI version, the spec is
+// validated with, or "" without a spec.
+func (e *itemEncoder) specDraft() string {
+	if e.validator == nil {
+		return ""
+	}
+	return e.validator.Draft()
+}
+
+// encodeJSON parses a JSON document and converts it into DynamoDB attribute
+// values.
+func (e *itemEncoder) encodeJSON(input string) (map[string]types.AttributeValue, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var jInt interface{}
+	if err := unmarshalJSONNumbers([]byte(input), &jInt); err != nil {
+		diags.AddAttributeError(
+			path.Root("json"),
+			"JSON Handling Failed",
+			fmt.Sprintf("The provider received an unexpected error while attempting to parse the JSON.\n\nError: %s", err),
+		)
+		return nil, diags
+	}
+
+	if _, ok := jInt.(map[string]interface{}); !ok {
+		diags.AddAttributeError(
+			path.Root("json"),
+			"Unsupported JSON Value",
+			fmt.Sprintf("A DynamoDB item must be a JSON object, got: %s. Use the json2dynamodb_items data source to convert a JSON array or JSON Lines of items.", jsonTypeName(jInt)),
+		)
+		return nil, diags
+	}
+
+	return e.encode(jInt)
+}
+
+// encodeJSONItems parses a JSON array or JSON Lines stream of items and
+// converts each into DynamoDB attribute values. Diagnostics name the index of
+// the item they belong to.
+func (e *itemEncoder) encodeJSONItems(input string) ([]map[string]types.AttributeValue, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	items, err := unmarshalJSONItems([]byte(input))
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("json"),
+			"JSON Handling Failed",
+			fmt.Sprintf("The provider received an unexpected error while attempting to parse the JSON items.\n\nError: %s", err),
+		)
+		return nil, diags
+	}
+
+	converted := make([]map[string]types.AttributeValue, 0, len(items))
+	for i, item := range items {
+		applied := len(e.appliedChanges)
+		avs, itemDiags := e.encode(item)
+		converted = append(converted, avs)
+		diags.Append(itemDiagnostics(i, itemDiags)...)
+		for j := applied; j < len(e.appliedChanges); j++ {
+			e.appliedChanges[j] = fmt.Sprintf("item %d: %s", i, e.appliedChanges[j])
+		}
+	}
+
+	if diags.HasError() {
+		return nil, diags
+	}
+	return converted, diags
+}
+
+// encode rewrites a decoded JSON item as apply_defaults and coerce_types say,
+// validates it against the spec and converts it into DynamoDB attribute
+// values. Diagnostics are reported against the "json" and "spec" attributes.
+func (e *itemEncoder) encode(item interface{}) (map[string]types.AttributeValue, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var schemaHints map[string]typeHint
+
+	if _, ok := item.(map[string]interface{}); !ok {
+		diags.AddAttributeError(
+			path.Root("json"),
+			"Unsupported JSON Value",
+			fmt.Sprintf("A DynamoDB item must be a JSON object, got: %s.", jsonTypeName(item)),
+		)
+		return nil, diags
+	}
+
+	if e.opts.rewritesItems() {
+		rewritten, changes, err := applySchema(e.schema, item, e.opts.ApplyDefaults, e.opts.CoerceTypes)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("spec"),
+				"JSON Spec Rewrite Failed",
+				fmt.Sprintf("The provider received an unexpected error while attempting to apply the defaults and types of the schema.\n\nError: %s", err),
+			)
+			return nil, diags
+		}
+		item = rewritten
+		e.appliedChanges = append(e.appliedChanges, changes...)
+	}
+
+	if e.validator != nil {
+		if !e.severity.disabled() {
+			diags.Append(specViolationDiagnostics(item, e.validator.Validate(item), e.severity)...)
+			if diags.HasError() {
+				return nil, diags
+			}
+		}
+
+		if e.opts.SchemaTypes {
+			var err error
+			if schemaHints, err = schemaTypeHints(e.schema, item, e.opts.EpochDateTimes); err != nil {
+				diags.AddAttributeError(
+					path.Root("spec"),
+					"JSON Spec Type Derivation Failed",
+					fmt.Sprintf("The provider received an unexpected error while attempting to derive DynamoDB types from the OpenAPI Specification.\n\nError: %s", err),
+				)
+				return nil, diags
+			}
+		}
+	}
+
+	marshaler := &attributeMarshaler{typeHints: e.typeHints, schemaHints: schemaHints}
+	avs, err := marshaler.marshalMap(item)
+	var nErr *numberError
+	if errors.As(err, &nErr) {
+		diags.AddAttributeError(
+			path.Root("json"),
+			"Invalid DynamoDB Number",
+			fmt.Sprintf("The JSON contains a number DynamoDB cannot store. DynamoDB numbers have at most %d significant digits and a magnitude between 1E%d and 9.9999999999999999999999999999999999999E+%d.\n\nError: %s", dynamoDBNumberPrecision, dynamoDBNumberMinExponent, dynamoDBNumberMaxExponent, err),
+		)
+		return nil, diags
+	}
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("json"),
+			"DynamoDB JSON Marshalling Failed",
+			fmt.Sprintf("The provider received an unexpected error while attempting to transform the JSON into DynamoDB Attribute Values.\n\nError: %s", err),
+		)
+		return nil, diags
+	}
+
+	for _, v := range itemLimitViolations(avs) {
+		summary := "DynamoDB Item Limit Exceeded"
+		detail := fmt.Sprintf("DynamoDB would reject this item when it is written.\n\nError: %s", v)
+		if e.opts.LimitViolations == limitViolationsWarn {
+			diags.AddAttributeWarning(path.Root("json"), summary, detail)
+		} else {
+			diags.AddAttributeError(path.Root("json"), summary, detail)
+		}
+	}
+
+	if diags.HasError() {
+		return nil, diags
+	}
+	return avs, diags
+}
+
+// serializeItem renders an item as DynamoDB JSON.
+func serializeItem(avs map[string]types.AttributeValue) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	jsonBytes, err := SerializeAttributeMap(avs)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("json"),
+			"DynamoDB JSON Serialization Failed",
+			fmt.Sprintf("The provider received an unexpected error while attempting to transform the DynamoDB Attribute Values into DynamoDB JSON Format.\n\nError: %s", err),
+		)
+		return "", diags
+	}
+	return string(jsonBytes), diags
+}
+
+// unmarshalJSONNumbers decodes JSON like json.Unmarshal, but keeps numbers
+// as json.Number so they are never rounded through float64.
+func unmarshalJSONNumbers(data []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(v); err != nil {
+		return jsonPositionError(data, err)
+	}
+	rest := data[dec.InputOffset():]
+	if _, err := dec.Token(); err != io.EOF {
+		offset := int64(len(data)-len(bytes.TrimLeft(rest, " \t\r\n"))) + 1
+		return fmt.Errorf("%s: unexpected data after top-level value", jsonPosition(data, offset))
+	}
+	return nil
+}
+
+// jsonPositionError adds the line and column a JSON decoding error occurred
+// at to the error.
+func jsonPositionError(data []byte, err error) error {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		return fmt.Errorf("%s: %w", jsonPosition(data, syntaxErr.Offset), err)
+	case errors.As(err, &typeErr):
+		return fmt.Errorf("%s: %w", jsonPosition(data, typeErr.Offset), err)
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return fmt.Errorf("%s: %w", jsonPosition(data, int64(len(data))), err)
+	}
+	return err
+}
+
+// jsonPosition returns the line and column, counted from 1, of the last of
+// the first offset bytes of data, which is where the decoder stopped.
+func jsonPosition(data []byte, offset int64) string {
+	before := data[:min(max(offset, 0), int64(len(data)))]
+	line := bytes.Count(before, []byte("\n")) + 1
+	column := max(utf8.RuneCount(before[bytes.LastIndexByte(before, '\n')+1:]), 1)
+	return fmt.Sprintf("line %d, column %d", line, column)
+}
+
+// unmarshalJSONItems decodes a JSON array of items, or JSON Lines with one
+// item per line, keeping numbers as json.Number.
+func unmarshalJSONItems(data []byte) ([]interface{}, error) {
+	if trimmed := bytes.TrimSpace(data); bytes.HasPrefix(trimmed, []byte("[")) {
+		var items []interface{}
+		if err := unmarshalJSONNumbers(trimmed, &items); err != nil {
+			return nil, err
+		}
+		return items, nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var items []interface{}
+	for {
+		var item interface{}
+		err := dec.Decode(&item)
+		if err == io.EOF {
+			return items, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", len(items), jsonPositionError(data, err))
+		}
+		items = append(items, item)
+	}
+}
+
+// itemDiagnostics prefixes the details of diagnostics with the index of the
+// item they belong to.
+func itemDiagnostics(i int, diags diag.Diagnostics) diag.Diagnostics {
+	var itemDiags diag.Diagnostics
+	for _, d := range diags {
+		summary := d.Summary()
+		detail := fmt.Sprintf("Item %d: %s", i, d.Detail())
+
+		dp, ok := d.(diag.DiagnosticWithPath)
+		switch {
+		case ok && d.Severity() == diag.SeverityError:
+			itemDiags.AddAttributeError(dp.Path(), summary, detail)
+		case ok:
+			itemDiags.AddAttributeWarning(dp.Path(), summary, detail)
+		case d.Severity() == diag.SeverityError:
+			itemDiags.AddError(summary, detail)
+		default:
+			itemDiags.AddWarning(summary, detail)
+		}
+	}
+	return itemDiags
+}
+
+// attributeDiagnostics reports diagnostics of the "json" attribute against
+// the attribute p, for data sources that encode another attribute.
+func attributeDiagnostics(p path.Path, diags diag.Diagnostics) diag.Diagnostics {
+	var attrDiags diag.Diagnostics
+	for _, d := range diags {
+		if dp, ok := d.(diag.DiagnosticWithPath); ok && dp.Path().Equal(path.Root("json")) {
+			d = diag.WithPath(p, d)
+		}
+		attrDiags.Append(d)
+	}
+	return attrDiags
+}
+
+// attributeMarshaler converts decoded JSON into DynamoDB attribute values.
+// Unlike attributevalue.MarshalMap it writes json.Number values to N verbatim,
+// so the stored number is exactly what appeared in the source JSON.
+type attributeMarshaler struct {
+	typeHints []typeHint
+	// schemaHints are derived from the spec, keyed by JSON Pointer. Explicit
+	// type hints take precedence over them.
+	schemaHints map[string]typeHint
+}
+
+// marshalMap converts a decoded JSON object into an item.
+func (m *attributeMarshaler) marshalMap(v interface{}) (map[string]types.AttributeValue, error) {
+	if _, ok := v.(map[string]interface{}); !ok {
+		return nil, fmt.Errorf("expected a JSON object, got %s instead", jsonTypeName(v))
+	}
+	av, err := m.marshal(v, nil)
+	if err != nil {
+		return nil, err
+	}
+	return av.(*types.AttributeValueMemberM).Value, nil
+}
+
+// marshal converts the decoded JSON value at the given path into its DynamoDB
+// attribute value. Errors name the JSON Pointer of the offending value.
+func (m *attributeMarshaler) marshal(v interface{}, segments []string) (types.AttributeValue, error) {
+	hint, err := matchTypeHint(m.typeHints, segments)
+	if err != nil {
+		return nil, fmt.Errorf("at %s: %w", formatPointer(segments), err)
+	}
+	if sh, ok := m.schemaHints[formatPointer(segments)]; ok && hint == nil {
+		hint = &sh
+	}
+	if hint != nil {
+		av, err := hintedAttributeValue(v, *hint)
+		if err != nil {
+			return nil, fmt.Errorf("at %s: %s: %w", formatPointer(segments), hint.Source, err)
+		}
+		return av, nil
+	}
+
+	switch uv := v.(type) {
+	case nil:
+		return &types.AttributeValueMemberNULL{Value: true}, nil
+
+	case bool:
+		return &types.AttributeValueMemberBOOL{Value: uv}, nil
+
+	case json.Number:
+		if err := validateDynamoDBNumber(uv.String()); err != nil {
+			return nil, fmt.Errorf("at %s: %w", formatPointer(segments), err)
+		}
+		return &types.AttributeValueMemberN{Value: uv.String()}, nil
+
+	case string:
+		return &types.AttributeValueMemberS{Value: uv}, nil
+
+	case []interface{}:
+		l := make([]types.AttributeValue, 0, len(uv))
+		for i, e := range uv {
+			av, err := m.marshal(e, append(slices.Clip(segments), strconv.Itoa(i)))
+			if err != nil {
+				return nil, err
+			}
+			l = append(l, av)
+		}
+		return &types.AttributeValueMemberL{Value: l}, nil
+
+	case map[string]interface{}:
+		mv := make(map[string]types.AttributeValue, len(uv))
+		for k, e := range uv {
+			av, err := m.marshal(e, append(slices.Clip(segments), k))
+			if err != nil {
+				return nil, err
+			}
+			mv[k] = av
+		}
+		return &types.AttributeValueMemberM{Value: mv}, nil
+
+	default:
+		return nil, fmt.Errorf("at %s: unsupported JSON value of type %T", formatPointer(segments), uv)
+	}
+}
+
+// jsonTypeName returns the JSON type name of a decoded JSON value.
+func jsonTypeName(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case json.Number, float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
+
+const (
+	// setFormatArray renders DynamoDB sets as JSON arrays in stored order.
+	setFormatArray = "array"
+	// setFormatSorted renders DynamoDB sets as sorted JSON arrays, so the
+	// output is stable regardless of the order DynamoDB returns members in.
+	setFormatSorted = "sorted"
+
+	// binaryFormatBase64 renders binary values as standard base64 strings.
+	binaryFormatBase64 = "base64"
+	// binaryFormatUTF8 renders binary values as UTF-8 strings.
+	binaryFormatUTF8 = "utf8"
+)
+
+// decodeOptions controls how DynamoDB JSON is converted back into plain JSON.
+type decodeOptions struct {
+	// SetFormat is one of setFormatArray (the default) or setFormatSorted.
+	SetFormat string
+	// BinaryFormat is one of binaryFormatBase64 (the default) or binaryFormatUTF8.
+	BinaryFormat string
+}
+
+// setDefaults validates the options and fills in their defaults.
+// Diagnostics are reported against the "set_format" and "binary_format"
+// attributes.
+func (o *decodeOptions) setDefaults() diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	switch o.SetFormat {
+	case "":
+		o.SetFormat = setFormatArray
+	case setFormatArray, setFormatSorted:
+	default:
+		diags.AddAttributeError(
+			path.Root("set_format"),
+			"Invalid Set Format",
+			fmt.Sprintf("Expected one of %q or %q, got: %q.", setFormatArray, setFormatSorted, o.SetFormat),
+		)
+	}
+
+	switch o.BinaryFormat {
+	case "":
+		o.BinaryFormat = binaryFormatBase64
+	case binaryFormatBase64, binaryFormatUTF8:
+	default:
+		diags.AddAttributeError(
+			path.Root("binary_format"),
+			"Invalid Binary Format",
+			fmt.Sprintf("Expected one of %q or %q, got: %q.", binaryFormatBase64, binaryFormatUTF8, o.BinaryFormat),
+		)
+	}
+	return diags
+}
+
+// decodeJSON converts a DynamoDB JSON item back into plain JSON. It is shared
+// by the json2dynamodb_decode data source and the decode function.
+// Diagnostics are reported against the "dynamodb_json", "set_format" and
+// "binary_format" attributes.
+func decodeJSON(input string, opts decodeOptions) (string, diag.Diagnostics) {
+	diags := opts.setDefaults()
+
+	if diags.HasError() {
+		return "", diags
+	}
+
+	avs, err := DeserializeAttributeMap([]byte(input))
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("dynamodb_json"),
+			"DynamoDB JSON Deserialization Failed",
+			fmt.Sprintf("The provider received an unexpected error while attempting to parse the DynamoDB JSON into DynamoDB Attribute Values.\n\nError: %s", err),
+		)
+		return "", diags
+	}
+
+	jInt, err := attributeValueToInterface(&types.AttributeValueMemberM{Value: avs}, opts)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("dynamodb_json"),
+			"DynamoDB JSON Unmarshalling Failed",
+			fmt.Sprintf("The provider received an unexpected error while attempting to transform the DynamoDB Attribute Values into JSON.\n\nError: %s", err),
+		)
+		return "", diags
+	}
+
+	jsonBytes, err := json.Marshal(jInt)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("dynamodb_json"),
+			"JSON Serialization Failed",
+			fmt.Sprintf("The provider received an unexpected error while attempting to render the JSON.\n\nError: %s", err),
+		)
+		return "", diags
+	}
+	return string(jsonBytes), diags
+}
+
+// attributeValueToInterface walks an attribute value and returns the plain
+// JSON equivalent. Numbers are returned as json.Number so no precision is lost.
+func attributeValueToInterface(v types.AttributeValue, opts decodeOptions) (interface{}, error) {
+	switch uv := v.(type) {
+	case *types.AttributeValueMemberB:
+		return binaryToInterface(uv.Value, opts)
+
+	case *types.AttributeValueMemberBOOL:
+		return uv.Value, nil
+
+	case *types.AttributeValueMemberBS:
+		members := uv.Value
+		if opts.SetFormat == setFormatSorted {
+			members = slices.Clone(members)
+			slices.SortFunc(members, bytes.Compare)
+		}
+		l := make([]interface{}, 0, len(members))
+		for _, m := range members {
+			b, err := binaryToInterface(m, opts)
+			if err != nil {
+				return nil, err
+			}
+			l = append(l, b)
+		}
+		return l, nil
+
+	case *types.AttributeValueMemberL:
+		l := make([]interface{}, 0, len(uv.Value))
+		for i, m := range uv.Value {
+			jv, err := attributeValueToInterface(m, opts)
+			if err != nil {
+				return nil, fmt.Errorf("[%d]: %w", i, err)
+			}
+			l = append(l, jv)
+		}
+		return l, nil
+
+	case *types.AttributeValueMemberM:
+		m := make(map[string]interface{}, len(uv.Value))
+		for k, mv := range uv.Value {
+			jv, err := attributeValueToInterface(mv, opts)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", k, err)
+			}
+			m[k] = jv
+		}
+		return m, nil
+
+	case *types.AttributeValueMemberN:
+		return numberToInterface(uv.Value)
+
+	case *types.AttributeValueMemberNS:
+		members := uv.Value
+		if opts.SetFormat == setFormatSorted {
+			var err error
+			if members, err = sortNumberStrings(members); err != nil {
+				return nil, err
+			}
+		}
+		l := make([]interface{}, 0, len(members))
+		for _, m := range members {
+			n, err := numberToInterface(m)
+			if err != nil {
+				return nil, err
+			}
+			l = append(l, n)
+		}
+		return l, nil
+
+	case *types.AttributeValueMemberNULL:
+		return nil, nil
+
+	case *types.AttributeValueMemberS:
+		return uv.Value, nil
+
+	case *types.AttributeValueMemberSS:
+		members := uv.Value
+		if opts.SetFormat == setFormatSorted {
+			members = slices.Clone(members)
+			slices.Sort(members)
+		}
+		l := make([]interface{}, 0, len(members))
+		for _, m := range members {
+			l = append(l, m)
+		}
+		return l, nil
+
+	case *types.UnknownUnionMember:
+		return nil, fmt.Errorf("unknown attribute value type %q", uv.Tag)
+
+	case nil:
+		return nil, fmt.Errorf("missing attribute value")
+
+	default:
+		return nil, fmt.Errorf("unexpected attribute value type %T", uv)
+	}
+}
+
+func binaryToInterface(b []byte, opts decodeOptions) (interface{}, error) {
+	if opts.BinaryFormat == binaryFormatUTF8 {
+		if !utf8.Valid(b) {
+			return nil, fmt.Errorf("binary value is not valid UTF-8")
+		}
+		return string(b), nil
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+// jsonNumberPattern matches a JSON number literal.
+var jsonNumberPattern = regexp.MustCompile(`^-?(?:0|[1-9][0-9]*)(?:\.[0-9]+)?(?:[eE][+-]?[0-9]+)?$`)
+
+func numberToInterface(s string) (interface{}, error) {
+	if !jsonNumberPattern.MatchString(s) {
+		return nil, fmt.Errorf("invalid number %q", s)
+	}
+	return json.Number(s), nil
+}
+
+// sortNumberStrings returns a copy of the number set members in ascending
+// numeric order.
+func sortNumberStrings(members []string) ([]string, error) {
+	parsed := make(map[string]*big.Rat, len(members))
+	for _, m := range members {
+		f, ok := new(big.Rat).SetString(m)
+		if !jsonNumberPattern.MatchString(m) || !ok {
+			return nil, fmt.Errorf("invalid number %q", m)
+		}
+		parsed[m] = f
+	}
+	sorted := slices.Clone(members)
+	slices.SortStableFunc(sorted, func(a, b string) int {
+		return parsed[a].Cmp(parsed[b])
+	})
+	return sorted, nil
+}
@@ -2,22 +2,19 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"maps"
 
-	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
-	"github.com/go-openapi/spec"
-	"github.com/go-openapi/strfmt"
-	"github.com/go-openapi/validate"
+	awstypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ datasource.DataSource = &JSON2DynamoDBDataSource{}
+var _ datasource.DataSourceWithConfigure = &JSON2DynamoDBDataSource{}
 
 func NewJSON2DynamoDBDataSource() datasource.DataSource {
 	return &JSON2DynamoDBDataSource{}
@@ -25,15 +22,23 @@ func NewJSON2DynamoDBDataSource() datasource.DataSource {
 
 // JSON2DynamoDBDataSource defines the data source implementation.
 type JSON2DynamoDBDataSource struct {
-	// client *http.Client
+	specs *specCache
 }
 
 // JSON2DynamoDBDataSourceModel describes the data source data model.
 type JSON2DynamoDBDataSourceModel struct {
-	JSON   jsontypes.Normalized `tfsdk:"json"`
-	Spec   jsontypes.Normalized `tfsdk:"spec"`
-	Result jsontypes.Normalized `tfsdk:"result"`
-	Id     types.String         `tfsdk:"id"`
+	EncodeOptionsModel
+	WriteRequestsModel
+	PartiQLModel
+
+	JSON               jsontypes.Normalized `tfsdk:"json"`
+	KeySchema          *KeySchemaModel      `tfsdk:"key_schema"`
+	Result             jsontypes.Normalized `tfsdk:"result"`
+	Key                jsontypes.Normalized `tfsdk:"key"`
+	ItemSizeBytes      types.Int64          `tfsdk:"item_size_bytes"`
+	WriteCapacityUnits types.Int64          `tfsdk:"write_capacity_units"`
+	ReadCapacityUnits  types.Int64          `tfsdk:"read_capacity_units"`
+	Id                 types.String         `tfsdk:"id"`
 }
 
 func (d *JSON2DynamoDBDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -41,49 +46,60 @@ func (d *JSON2DynamoDBDataSource) Metadata(ctx context.Context, req datasource.M
 }
 
 func (d *JSON2DynamoDBDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := encodeOptionsAttributes()
+	maps.Copy(attributes, writeRequestsAttributes())
+	maps.Copy(attributes, partiQLAttributes())
+	maps.Copy(attributes, map[string]schema.Attribute{
+		"json": schema.StringAttribute{
+			MarkdownDescription: "JSON String",
+			Required:            true,
+			CustomType:          jsontypes.NormalizedType{},
+		},
+		"result": schema.StringAttribute{
+			MarkdownDescription: "JSON rendered as DynamoDB JSON",
+			Computed:            true,
+			CustomType:          jsontypes.NormalizedType{},
+		},
+		"key": schema.StringAttribute{
+			MarkdownDescription: "The primary key of the item in DynamoDB JSON, as `aws_dynamodb_table_item` and GetItem take it. Null without `key_schema`.",
+			Computed:            true,
+			CustomType:          jsontypes.NormalizedType{},
+		},
+		"item_size_bytes": schema.Int64Attribute{
+			MarkdownDescription: "Size of the item as DynamoDB bills it, counting attribute names and values",
+			Computed:            true,
+		},
+		"write_capacity_units": schema.Int64Attribute{
+			MarkdownDescription: fmt.Sprintf("Write capacity units one standard write of the item consumes, one per %d bytes. Transactional writes consume twice as many.", writeCapacityUnitSize),
+			Computed:            true,
+		},
+		"read_capacity_units": schema.Int64Attribute{
+			MarkdownDescription: fmt.Sprintf("Read capacity units one strongly consistent read of the item consumes, one per %d bytes. Eventually consistent reads consume half as many, transactional reads twice as many.", readCapacityUnitSize),
+			Computed:            true,
+		},
+		"id": schema.StringAttribute{
+			MarkdownDescription: "The ID of this data source",
+			Computed:            true,
+		},
+	})
+
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "JSON into DynamoDB JSON format",
 
-		Attributes: map[string]schema.Attribute{
-			"json": schema.StringAttribute{
-				MarkdownDescription: "JSON String",
-				Required:            true,
-				CustomType:          jsontypes.NormalizedType{},
-			},
-			"spec": schema.StringAttribute{
-				MarkdownDescription: "OpenAPI Schema specification in JSON format to validate the JSON against.",
-				Optional:            true,
-				CustomType:          jsontypes.NormalizedType{},
-			},
-			"result": schema.StringAttribute{
-				MarkdownDescription: "JSON rendered as DynamoDB JSON",
-				Computed:            true,
-				CustomType:          jsontypes.NormalizedType{},
-			},
-			"id": schema.StringAttribute{
-				MarkdownDescription: "The ID of this data source",
-				Computed:            true,
-			},
+		Attributes: attributes,
+		Blocks: map[string]schema.Block{
+			"key_schema": keySchemaBlock(),
 		},
 	}
 }
 
 func (d *JSON2DynamoDBDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
-	// Prevent panic if the provider has not been configured.
-	// if req.ProviderData == nil {
-	// 	return
-	// }
-	// client, ok := req.ProviderData.(*http.Client)
-	//if !ok {
-	//	resp.Diagnostics.AddError(
-	//		"Unexpected Data Source Configure Type",
-	//		fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
-	//	)
-	//
-	//	return
-	//}
-
-	// d.client = client
+	pd, diags := configuredProviderData(req.ProviderData)
+	resp.Diagnostics.Append(diags...)
+
+	if pd != nil {
+		d.specs = pd.specs
+	}
 }
 
 func (d *JSON2DynamoDBDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -96,55 +112,58 @@ func (d *JSON2DynamoDBDataSource) Read(ctx context.Context, req datasource.ReadR
 		return
 	}
 
-	var jInt interface{}
-	if err := json.Unmarshal([]byte(data.JSON.ValueString()), &jInt); err != nil {
-		resp.Diagnostics.AddAttributeError(
-			path.Root("json"),
-			"JSON Handling Failed",
-			"The data source received an unexpected error while attempting to parse the JSON.",
-		)
+	opts, diags := data.encodeOptions(ctx, d.specs)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if data.Spec.ValueString() != "" {
-		schema := new(spec.Schema)
-		if err := schema.UnmarshalJSON([]byte(data.Spec.ValueString())); err != nil {
-			resp.Diagnostics.AddAttributeError(
-				path.Root("spec"),
-				"JSON Spec Handling Failed",
-				fmt.Sprintf("The data source received an unexpected error while attempting to build the OpenAPI Specification.\n\nError: %s", err),
-			)
-			return
-		}
+	encoder, diags := newItemEncoder(opts)
+	resp.Diagnostics.Append(diags...)
 
-		if err := validate.AgainstSchema(schema, jInt, strfmt.Default); err != nil {
-			resp.Diagnostics.AddAttributeError(
-				path.Root("json"),
-				"JSON Spec Validation Failure",
-				fmt.Sprint(err),
-			)
-			return
-		}
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	avs, err := attributevalue.MarshalMap(&jInt)
-	if err != nil {
-		resp.Diagnostics.AddAttributeError(
-			path.Root("json"),
-			"DynamoDB JSON Marshalling Failed",
-			fmt.Sprintf("The data source received an unexpected error while attempting to transform the JSON into DynamoDB Attribute Values.\n\nError: %s", err),
-		)
+
+	data.setSpecDraft(encoder)
+
+	avs, diags := encoder.encodeJSON(data.JSON.ValueString())
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(data.setAppliedChanges(ctx, encoder)...)
+
+	if resp.Diagnostics.HasError() {
 		return
 	}
-	jsonBytes, err := SerializeAttributeMap(avs)
-	if err != nil {
-		resp.Diagnostics.AddAttributeError(
-			path.Root("json"),
-			"DynamoDB JSON Serialization Failed",
-			fmt.Sprintf("The data source received an unexpected error while attempting to transform the DynamoDB Attribute Values into DynamoDB JSON Format.\n\nError: %s", err),
-		)
+
+	data.Key = jsontypes.NewNormalizedNull()
+	if data.KeySchema != nil {
+		key, diags := data.KeySchema.primaryKey(avs, opts.LimitViolations)
+		resp.Diagnostics.Append(diags...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		keyJSON, diags := serializeItem(key)
+		resp.Diagnostics.Append(diags...)
+		data.Key = jsontypes.NewNormalizedValue(keyJSON)
+	}
+
+	result, diags := serializeItem(avs)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(data.setWriteRequests(ctx, []map[string]awstypes.AttributeValue{avs})...)
+	resp.Diagnostics.Append(data.setPartiQL(ctx, data.TableName.ValueString(), avs)...)
+
+	if resp.Diagnostics.HasError() {
 		return
 	}
-	data.Result = jsontypes.NewNormalizedValue(string(jsonBytes))
+
+	size := itemSize(avs)
+	data.Result = jsontypes.NewNormalizedValue(result)
+	data.ItemSizeBytes = types.Int64Value(size)
+	data.WriteCapacityUnits = types.Int64Value(capacityUnits(size, writeCapacityUnitSize))
+	data.ReadCapacityUnits = types.Int64Value(capacityUnits(size, readCapacityUnitSize))
 	data.Id = types.StringValue("-")
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &JSON2DynamoDBDecodeDataSource{}
+
+func NewJSON2DynamoDBDecodeDataSource() datasource.DataSource {
+	return &JSON2DynamoDBDecodeDataSource{}
+}
+
+// JSON2DynamoDBDecodeDataSource defines the data source implementation.
+type JSON2DynamoDBDecodeDataSource struct{}
+
+// JSON2DynamoDBDecodeDataSourceModel describes the data source data model.
+type JSON2DynamoDBDecodeDataSourceModel struct {
+	DynamoDBJSON jsontypes.Normalized `tfsdk:"dynamodb_json"`
+	SetFormat    types.String         `tfsdk:"set_format"`
+	BinaryFormat types.String         `tfsdk:"binary_format"`
+	Result       jsontypes.Normalized `tfsdk:"result"`
+	Id           types.String         `tfsdk:"id"`
+}
+
+func (d *JSON2DynamoDBDecodeDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_decode"
+}
+
+func (d *JSON2DynamoDBDecodeDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "DynamoDB JSON into JSON format",
+
+		Attributes: map[string]schema.Attribute{
+			"dynamodb_json": schema.StringAttribute{
+				MarkdownDescription: "DynamoDB JSON String, as an object of attribute values",
+				Required:            true,
+				CustomType:          jsontypes.NormalizedType{},
+			},
+			"set_format": schema.StringAttribute{
+				MarkdownDescription: "How `SS`, `NS` and `BS` sets are rendered: `array` keeps the stored order, `sorted` sorts the members. Defaults to `array`.",
+				Optional:            true,
+			},
+			"binary_format": schema.StringAttribute{
+				MarkdownDescription: "How `B` and `BS` values are rendered: `base64` as standard base64 strings, `utf8` as UTF-8 strings. Defaults to `base64`.",
+				Optional:            true,
+			},
+			"result": schema.StringAttribute{
+				MarkdownDescription: "DynamoDB JSON rendered as JSON",
+				Computed:            true,
+				CustomType:          jsontypes.NormalizedType{},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of this data source",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *JSON2DynamoDBDecodeDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JSON2DynamoDBDecodeDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, diags := decodeJSON(data.DynamoDBJSON.ValueString(), decodeOptions{
+		SetFormat:    data.SetFormat.ValueString(),
+		BinaryFormat: data.BinaryFormat.ValueString(),
+	})
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Result = jsontypes.NewNormalizedValue(result)
+	data.Id = types.StringValue("-")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+const testDecodeDataSourceConfig_basic = `
+data "json2dynamodb_decode" "test" {
+  dynamodb_json = jsonencode({
+    name     = { S = "briansenvtest" }
+    count    = { N = "12345678901234567890" }
+    enabled  = { BOOL = true }
+    nothing  = { NULL = true }
+    tags     = { SS = ["b", "a"] }
+    ports    = { NS = ["443", "80"] }
+    blob     = { B = "aGVsbG8=" }
+    config   = { M = { abc = { L = [{ S = "x" }, { N = "1.5" }] } } }
+  })
+}
+
+data "json2dynamodb_decode" "sorted" {
+  dynamodb_json = data.json2dynamodb_decode.test.dynamodb_json
+  set_format    = "sorted"
+  binary_format = "utf8"
+}
+
+output "plain" {
+  value = data.json2dynamodb_decode.test.result
+}
+
+output "sorted" {
+  value = data.json2dynamodb_decode.sorted.result
+}
+`
+
+const testDecodeDataSourceConfig_invalid = `
+data "json2dynamodb_decode" "test" {
+  dynamodb_json = jsonencode({ name = { X = "briansenvtest" } })
+}
+`
+
+func TestDecodeDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		Steps: []resource.TestStep{
+			{
+				Config: testDecodeDataSourceConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("plain",
+						`{"blob":"aGVsbG8=","config":{"abc":["x",1.5]},"count":12345678901234567890,"enabled":true,"name":"briansenvtest","nothing":null,"ports":[443,80],"tags":["b","a"]}`,
+					),
+					resource.TestCheckOutput("sorted",
+						`{"blob":"hello","config":{"abc":["x",1.5]},"count":12345678901234567890,"enabled":true,"name":"briansenvtest","nothing":null,"ports":[80,443],"tags":["a","b"]}`,
+					),
+				),
+			},
+			{
+				Config:      testDecodeDataSourceConfig_invalid,
+				ExpectError: regexp.MustCompile(`unknown attribute value type "X"`),
+			},
+		},
+	})
+}
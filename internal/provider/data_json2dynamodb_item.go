@@ -0,0 +1,235 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Behaviours of the json2dynamodb_item data source when the item does not
+// exist.
+const (
+	notFoundError = "error"
+	notFoundNull  = "null"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &JSON2DynamoDBItemDataSource{}
+var _ datasource.DataSourceWithConfigure = &JSON2DynamoDBItemDataSource{}
+
+func NewJSON2DynamoDBItemDataSource() datasource.DataSource {
+	return &JSON2DynamoDBItemDataSource{}
+}
+
+// JSON2DynamoDBItemDataSource defines the data source implementation.
+type JSON2DynamoDBItemDataSource struct {
+	client *dynamoDBClient
+}
+
+// JSON2DynamoDBItemDataSourceModel describes the data source data model.
+type JSON2DynamoDBItemDataSourceModel struct {
+	TableName                types.String         `tfsdk:"table_name"`
+	Key                      jsontypes.Normalized `tfsdk:"key"`
+	TypeHints                types.Map            `tfsdk:"type_hints"`
+	ConsistentRead           types.Bool           `tfsdk:"consistent_read"`
+	ProjectionExpression     types.String         `tfsdk:"projection_expression"`
+	ExpressionAttributeNames types.Map            `tfsdk:"expression_attribute_names"`
+	NotFound                 types.String         `tfsdk:"not_found"`
+	SetFormat                types.String         `tfsdk:"set_format"`
+	BinaryFormat             types.String         `tfsdk:"binary_format"`
+	Endpoint                 types.String         `tfsdk:"endpoint"`
+	JSON                     jsontypes.Normalized `tfsdk:"json"`
+	Id                       types.String         `tfsdk:"id"`
+}
+
+func (d *JSON2DynamoDBItemDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_item"
+}
+
+func (d *JSON2DynamoDBItemDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads one item of a DynamoDB table with GetItem, by its key in plain JSON, and returns the item as plain JSON",
+
+		Attributes: map[string]schema.Attribute{
+			"table_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the table",
+				Required:            true,
+			},
+			"key": schema.StringAttribute{
+				MarkdownDescription: "The key attributes of the item as a JSON object, e.g. `{\"pk\":\"tenant#1\",\"sk\":1}`",
+				Required:            true,
+				CustomType:          jsontypes.NormalizedType{},
+			},
+			"type_hints": schema.MapAttribute{
+				MarkdownDescription: "Map of JSON Pointer patterns to the DynamoDB type the matching key values are encoded as, e.g. `{ \"/id\" = \"B\" }` for a base64 binary key",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"consistent_read": schema.BoolAttribute{
+				MarkdownDescription: "Read the item with a strongly consistent read. Defaults to `false`.",
+				Optional:            true,
+			},
+			"projection_expression": schema.StringAttribute{
+				MarkdownDescription: "Attributes to read, e.g. `enabled, limits.daily`. Defaults to all attributes.",
+				Optional:            true,
+			},
+			"expression_attribute_names": schema.MapAttribute{
+				MarkdownDescription: "Substitutions for attribute names in `projection_expression`, e.g. `{ \"#name\" = \"name\" }` for reserved words",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"not_found": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("What happens when the table has no item with the key: `%s` fails the read, `%s` sets `json` to null. Defaults to `%s`.", notFoundError, notFoundNull, notFoundError),
+				Optional:            true,
+			},
+			"set_format": schema.StringAttribute{
+				MarkdownDescription: "How `SS`, `NS` and `BS` sets are rendered: `array` keeps the stored order, `sorted` sorts the members. Defaults to `array`.",
+				Optional:            true,
+			},
+			"binary_format": schema.StringAttribute{
+				MarkdownDescription: "How `B` and `BS` values are rendered: `base64` as standard base64 strings, `utf8` as UTF-8 strings. Defaults to `base64`.",
+				Optional:            true,
+			},
+			"endpoint": schema.StringAttribute{
+				MarkdownDescription: "URL of the DynamoDB endpoint, e.g. `http://localhost:8000` for DynamoDB Local. Defaults to the `endpoint` of the provider.",
+				Optional:            true,
+			},
+			"json": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("The item as plain JSON. Null when the item does not exist and `not_found` is `%s`.", notFoundNull),
+				Computed:            true,
+				CustomType:          jsontypes.NormalizedType{},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The table name and the key of the item in plain JSON, e.g. `settings/{\"tenant\":\"a\"}`",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *JSON2DynamoDBItemDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	pd, diags := configuredProviderData(req.ProviderData)
+	resp.Diagnostics.Append(diags...)
+
+	if pd != nil {
+		d.client = pd.dynamodb
+	}
+}
+
+func (d *JSON2DynamoDBItemDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JSON2DynamoDBItemDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	notFound := data.NotFound.ValueString()
+	switch notFound {
+	case "":
+		notFound = notFoundError
+	case notFoundError, notFoundNull:
+	default:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("not_found"),
+			"Invalid Not Found Behaviour",
+			fmt.Sprintf("Expected one of %q or %q, got: %q.", notFoundError, notFoundNull, notFound),
+		)
+	}
+
+	opts := decodeOptions{
+		SetFormat:    data.SetFormat.ValueString(),
+		BinaryFormat: data.BinaryFormat.ValueString(),
+	}
+	resp.Diagnostics.Append(opts.setDefaults()...)
+
+	var typeHints, names map[string]string
+	resp.Diagnostics.Append(data.TypeHints.ElementsAs(ctx, &typeHints, false)...)
+	resp.Diagnostics.Append(data.ExpressionAttributeNames.ElementsAs(ctx, &names, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	key, diags := encodeItemJSON(data.Key.ValueString(), encodeOptions{TypeHints: typeHints})
+	resp.Diagnostics.Append(attributeDiagnostics(path.Root("key"), diags)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := tableItemID(data.TableName.ValueString(), key)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("key"),
+			"DynamoDB Item Key Failed",
+			fmt.Sprintf("The provider received an unexpected error while attempting to render the key of the item.\n\nError: %s", err),
+		)
+		return
+	}
+
+	client, diags := d.client.get(ctx, data.Endpoint.ValueString())
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	input := &dynamodb.GetItemInput{
+		TableName:      aws.String(data.TableName.ValueString()),
+		Key:            key,
+		ConsistentRead: aws.Bool(data.ConsistentRead.ValueBool()),
+	}
+	if data.ProjectionExpression.ValueString() != "" {
+		input.ProjectionExpression = aws.String(data.ProjectionExpression.ValueString())
+	}
+	if len(names) > 0 {
+		input.ExpressionAttributeNames = names
+	}
+
+	out, err := client.GetItem(ctx, input)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"DynamoDB GetItem Failed",
+			fmt.Sprintf("The provider received an unexpected error while attempting to read the item.\n\nError: %s", err),
+		)
+		return
+	}
+
+	data.Id = types.StringValue(id)
+	data.JSON = jsontypes.NewNormalizedNull()
+
+	if out.Item == nil {
+		if notFound == notFoundError {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("key"),
+				"DynamoDB Item Not Found",
+				fmt.Sprintf("Table %q has no item with the key %s. Set not_found to %q to read a missing item as null.", data.TableName.ValueString(), data.Key.ValueString(), notFoundNull),
+			)
+			return
+		}
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	result, err := itemJSON(out.Item, opts)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"DynamoDB Item Decoding Failed",
+			fmt.Sprintf("The provider received an unexpected error while attempting to transform the stored item into JSON.\n\nError: %s", err),
+		)
+		return
+	}
+
+	data.JSON = jsontypes.NewNormalizedValue(result)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
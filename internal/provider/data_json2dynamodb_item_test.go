@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testItemDataSourceConfig(endpoint, options string) string {
+	return fmt.Sprintf(`
+provider "json2dynamodb" {
+  endpoint = %q
+}
+
+data "json2dynamodb_item" "test" {
+%s
+}
+
+output "json" {
+  value = data.json2dynamodb_item.test.json
+}
+`, endpoint, options)
+}
+
+func TestItemDataSource_basic(t *testing.T) {
+	fake := newFakeDynamoDB(t)
+	fake.createTable("settings", "tenant", "name")
+	fake.putItem(t, "settings", `{"tenant":{"S":"a"},"name":{"S":"flags"},"enabled":{"BOOL":true},"limit":{"N":"10"},"regions":{"SS":["us","eu"]},"logo":{"B":"aGVsbG8="}}`)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		Steps: []resource.TestStep{
+			{
+				Config: testItemDataSourceConfig(fake.URL, `
+  table_name      = "settings"
+  key             = jsonencode({ tenant = "a", name = "flags" })
+  consistent_read = true
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("json", `{"enabled":true,"limit":10,"logo":"aGVsbG8=","name":"flags","regions":["us","eu"],"tenant":"a"}`),
+					resource.TestCheckResourceAttr("data.json2dynamodb_item.test", "id", `settings/{"name":"flags","tenant":"a"}`),
+				),
+			},
+			{
+				Config: testItemDataSourceConfig(fake.URL, `
+  table_name                 = "settings"
+  key                        = jsonencode({ tenant = "a", name = "flags" })
+  projection_expression      = "#name, regions, logo"
+  expression_attribute_names = { "#name" = "name" }
+  set_format                 = "sorted"
+  binary_format              = "utf8"
+`),
+				Check: resource.TestCheckOutput("json", `{"logo":"hello","name":"flags","regions":["eu","us"]}`),
+			},
+			{
+				Config: testItemDataSourceConfig(fake.URL, `
+  table_name = "settings"
+  key        = jsonencode({ tenant = "b", name = "flags" })
+  not_found  = "null"
+`),
+				Check: resource.TestCheckNoResourceAttr("data.json2dynamodb_item.test", "json"),
+			},
+			{
+				Config: testItemDataSourceConfig(fake.URL, `
+  table_name = "settings"
+  key        = jsonencode({ tenant = "b", name = "flags" })
+`),
+				ExpectError: regexp.MustCompile(`Table "settings" has no item with the key`),
+			},
+			{
+				Config: testItemDataSourceConfig(fake.URL, `
+  table_name = "settings"
+  key        = jsonencode({ tenant = "a", name = "flags" })
+  not_found  = "empty"
+`),
+				ExpectError: regexp.MustCompile(`Expected one of "error" or "null", got: "empty"`),
+			},
+			{
+				Config: testItemDataSourceConfig(fake.URL, `
+  table_name = "missing"
+  key        = jsonencode({ tenant = "a" })
+`),
+				ExpectError: regexp.MustCompile(`ResourceNotFoundException`),
+			},
+			{
+				Config: testItemDataSourceConfig(fake.URL, `
+  table_name = "settings"
+  key        = "[1]"
+`),
+				ExpectError: regexp.MustCompile(`(?s)key\s+= "\[1\]".*A DynamoDB item must be a JSON object`),
+			},
+		},
+	})
+}
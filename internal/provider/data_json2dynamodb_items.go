@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"context"
+	"maps"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &JSON2DynamoDBItemsDataSource{}
+var _ datasource.DataSourceWithConfigure = &JSON2DynamoDBItemsDataSource{}
+
+func NewJSON2DynamoDBItemsDataSource() datasource.DataSource {
+	return &JSON2DynamoDBItemsDataSource{}
+}
+
+// JSON2DynamoDBItemsDataSource defines the data source implementation.
+type JSON2DynamoDBItemsDataSource struct {
+	specs *specCache
+}
+
+// JSON2DynamoDBItemsDataSourceModel describes the data source data model.
+type JSON2DynamoDBItemsDataSourceModel struct {
+	EncodeOptionsModel
+	WriteRequestsModel
+
+	JSON    types.String `tfsdk:"json"`
+	Results types.List   `tfsdk:"results"`
+	Id      types.String `tfsdk:"id"`
+}
+
+func (d *JSON2DynamoDBItemsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_items"
+}
+
+func (d *JSON2DynamoDBItemsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := encodeOptionsAttributes()
+	maps.Copy(attributes, writeRequestsAttributes())
+	maps.Copy(attributes, map[string]schema.Attribute{
+		"json": schema.StringAttribute{
+			MarkdownDescription: "JSON array of items, or JSON Lines with one item per line",
+			Required:            true,
+		},
+		"results": schema.ListAttribute{
+			MarkdownDescription: "Each item rendered as DynamoDB JSON, in input order",
+			Computed:            true,
+			ElementType:         jsontypes.NormalizedType{},
+		},
+		"id": schema.StringAttribute{
+			MarkdownDescription: "The ID of this data source",
+			Computed:            true,
+		},
+	})
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "JSON items into DynamoDB JSON format. Each item is validated against `spec` on its own.",
+
+		Attributes: attributes,
+	}
+}
+
+func (d *JSON2DynamoDBItemsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	pd, diags := configuredProviderData(req.ProviderData)
+	resp.Diagnostics.Append(diags...)
+
+	if pd != nil {
+		d.specs = pd.specs
+	}
+}
+
+func (d *JSON2DynamoDBItemsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JSON2DynamoDBItemsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	opts, diags := data.encodeOptions(ctx, d.specs)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	encoder, diags := newItemEncoder(opts)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.setSpecDraft(encoder)
+
+	converted, diags := encoder.encodeJSONItems(data.JSON.ValueString())
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(data.setAppliedChanges(ctx, encoder)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	results := make([]jsontypes.Normalized, 0, len(converted))
+	for i, avs := range converted {
+		result, diags := serializeItem(avs)
+		resp.Diagnostics.Append(itemDiagnostics(i, diags)...)
+		results = append(results, jsontypes.NewNormalizedValue(result))
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(data.setWriteRequests(ctx, converted)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Results, diags = types.ListValueFrom(ctx, jsontypes.NormalizedType{}, results)
+	resp.Diagnostics.Append(diags...)
+	data.Id = types.StringValue("-")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,165 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+const testItemsDataSourceConfig_array = `
+data "json2dynamodb_items" "test" {
+  json = jsonencode([
+    { pk = "tenant#1", name = "one" },
+    { pk = "tenant#2", name = "two", tags = ["a", "b"] },
+  ])
+
+  type_hints = { "/tags" = "SS" }
+  spec = jsonencode({
+    type     = "object"
+    required = ["pk"]
+  })
+}
+`
+
+const testItemsDataSourceConfig_lines = `
+data "json2dynamodb_items" "test" {
+  json = <<EOF
+{"pk": "tenant#1", "count": 1}
+
+{"pk": "tenant#2", "count": 2}
+EOF
+}
+`
+
+const testItemsDataSourceConfig_applyDefaults = `
+data "json2dynamodb_items" "test" {
+  json = jsonencode([
+    { pk = "tenant#1" },
+    { pk = "tenant#2", count = "2" },
+  ])
+
+  apply_defaults = true
+  coerce_types   = true
+  spec = jsonencode({
+    type = "object"
+    properties = {
+      count = { type = "integer", default = 0 }
+    }
+  })
+}
+`
+
+const testItemsDataSourceConfig_invalid = `
+data "json2dynamodb_items" "test" {
+  json = jsonencode([
+    { pk = "tenant#1" },
+    { name = "missing pk" },
+  ])
+
+  spec = jsonencode({
+    type     = "object"
+    required = ["pk"]
+  })
+}
+`
+
+const testItemsDataSourceConfig_writeRequests = `
+data "json2dynamodb_items" "test" {
+  json       = jsonencode([for i in range(27) : { pk = "item#${i}" }])
+  table_name = "seed"
+}
+
+data "json2dynamodb_items" "small" {
+  json                 = jsonencode([{ pk = "a" }, { pk = "b" }])
+  table_name           = "seed"
+  condition_expression = "attribute_not_exists(pk)"
+}
+`
+
+func TestItemsDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		Steps: []resource.TestStep{
+			{
+				Config: testItemsDataSourceConfig_array,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.json2dynamodb_items.test", "results.#", "2"),
+					resource.TestCheckResourceAttr("data.json2dynamodb_items.test", "results.0", `{"name":{"S":"one"},"pk":{"S":"tenant#1"}}`),
+					resource.TestCheckResourceAttr("data.json2dynamodb_items.test", "results.1", `{"name":{"S":"two"},"pk":{"S":"tenant#2"},"tags":{"SS":["a","b"]}}`),
+				),
+			},
+			{
+				Config: testItemsDataSourceConfig_applyDefaults,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.json2dynamodb_items.test", "results.0", `{"count":{"N":"0"},"pk":{"S":"tenant#1"}}`),
+					resource.TestCheckResourceAttr("data.json2dynamodb_items.test", "results.1", `{"count":{"N":"2"},"pk":{"S":"tenant#2"}}`),
+					resource.TestCheckResourceAttr("data.json2dynamodb_items.test", "applied_changes.#", "2"),
+					resource.TestCheckResourceAttr("data.json2dynamodb_items.test", "applied_changes.0", `item 0: /count: set default 0`),
+					resource.TestCheckResourceAttr("data.json2dynamodb_items.test", "applied_changes.1", `item 1: /count: coerced "2" to 2`),
+				),
+			},
+			{
+				Config: testItemsDataSourceConfig_lines,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.json2dynamodb_items.test", "results.#", "2"),
+					resource.TestCheckResourceAttr("data.json2dynamodb_items.test", "results.1", `{"count":{"N":"2"},"pk":{"S":"tenant#2"}}`),
+				),
+			},
+			{
+				Config:      testItemsDataSourceConfig_invalid,
+				ExpectError: regexp.MustCompile(`Item 1: at '': missing property 'pk'`),
+			},
+			{
+				Config: `
+data "json2dynamodb_items" "test" {
+  json = jsonencode([{ pk = "tenant#1" }, "tenant#2"])
+}
+`,
+				ExpectError: regexp.MustCompile(`(?m)Item 1: A DynamoDB item must be a JSON object, got: string\.$`),
+			},
+		},
+	})
+}
+
+func TestItemsDataSource_writeRequests(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		Steps: []resource.TestStep{
+			{
+				Config: testItemsDataSourceConfig_writeRequests,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.json2dynamodb_items.test", "batch_write_requests.#", "2"),
+					resource.TestCheckResourceAttrWith("data.json2dynamodb_items.test", "batch_write_requests.0", func(value string) error {
+						if n := strings.Count(value, "PutRequest"); n != 25 {
+							return fmt.Errorf("expected 25 requests in the first batch, got %d", n)
+						}
+						return nil
+					}),
+					resource.TestCheckResourceAttr("data.json2dynamodb_items.test", "batch_write_requests.1",
+						`{"RequestItems":{"seed":[{"PutRequest":{"Item":{"pk":{"S":"item#25"}}}},{"PutRequest":{"Item":{"pk":{"S":"item#26"}}}}]}}`,
+					),
+					resource.TestCheckResourceAttr("data.json2dynamodb_items.small", "transact_write_request",
+						`{"TransactItems":[{"Put":{"ConditionExpression":"attribute_not_exists(pk)","Item":{"pk":{"S":"a"}},"TableName":"seed"}},{"Put":{"ConditionExpression":"attribute_not_exists(pk)","Item":{"pk":{"S":"b"}},"TableName":"seed"}}]}`,
+					),
+				),
+			},
+			{
+				Config: `
+data "json2dynamodb_items" "test" {
+  json       = jsonencode([])
+  table_name = "seed"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.json2dynamodb_items.test", "batch_write_requests.#", "0"),
+					resource.TestCheckNoResourceAttr("data.json2dynamodb_items.test", "transact_write_request"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"context"
+	"maps"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	awstypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &JSON2DynamoDBQueryDataSource{}
+var _ datasource.DataSourceWithConfigure = &JSON2DynamoDBQueryDataSource{}
+
+func NewJSON2DynamoDBQueryDataSource() datasource.DataSource {
+	return &JSON2DynamoDBQueryDataSource{}
+}
+
+// JSON2DynamoDBQueryDataSource defines the data source implementation.
+type JSON2DynamoDBQueryDataSource struct {
+	client *dynamoDBClient
+}
+
+// JSON2DynamoDBQueryDataSourceModel describes the data source data model.
+type JSON2DynamoDBQueryDataSourceModel struct {
+	ReadItemsModel
+
+	KeyConditionExpression types.String `tfsdk:"key_condition_expression"`
+	ScanIndexForward       types.Bool   `tfsdk:"scan_index_forward"`
+}
+
+func (d *JSON2DynamoDBQueryDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_query"
+}
+
+func (d *JSON2DynamoDBQueryDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := readItemsAttributes()
+	maps.Copy(attributes, map[string]schema.Attribute{
+		"key_condition_expression": schema.StringAttribute{
+			MarkdownDescription: "Condition on the key attributes of the items to read, e.g. `tenant = :tenant AND begins_with(sk, :prefix)`",
+			Required:            true,
+		},
+		"scan_index_forward": schema.BoolAttribute{
+			MarkdownDescription: "Read the items in ascending order of their range key. Defaults to `true`.",
+			Optional:            true,
+		},
+	})
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads the items of a DynamoDB table or index that share a hash key with Query, following every page of results, and returns them as plain JSON",
+
+		Attributes: attributes,
+	}
+}
+
+func (d *JSON2DynamoDBQueryDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	pd, diags := configuredProviderData(req.ProviderData)
+	resp.Diagnostics.Append(diags...)
+
+	if pd != nil {
+		d.client = pd.dynamodb
+	}
+}
+
+func (d *JSON2DynamoDBQueryDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JSON2DynamoDBQueryDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	input, diags := data.input(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := d.client.get(ctx, data.Endpoint.ValueString())
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(data.readItems(ctx, "Query", func(ctx context.Context, startKey map[string]awstypes.AttributeValue, limit *int32) ([]map[string]awstypes.AttributeValue, map[string]awstypes.AttributeValue, error) {
+		out, err := client.Query(ctx, &dynamodb.QueryInput{
+			TableName:                 input.TableName,
+			IndexName:                 input.IndexName,
+			KeyConditionExpression:    aws.String(data.KeyConditionExpression.ValueString()),
+			FilterExpression:          input.FilterExpression,
+			ProjectionExpression:      input.ProjectionExpression,
+			ExpressionAttributeNames:  input.ExpressionAttributeNames,
+			ExpressionAttributeValues: input.ExpressionAttributeValues,
+			ConsistentRead:            input.ConsistentRead,
+			ScanIndexForward:          aws.Bool(data.ScanIndexForward.IsNull() || data.ScanIndexForward.ValueBool()),
+			ExclusiveStartKey:         startKey,
+			Limit:                     limit,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return out.Items, out.LastEvaluatedKey, nil
+	})...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
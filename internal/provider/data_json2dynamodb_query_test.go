@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testQueryDataSourceConfig(endpoint, dataSource, options string) string {
+	return fmt.Sprintf(`
+provider "json2dynamodb" {
+  endpoint = %q
+}
+
+data "json2dynamodb_%s" "test" {
+  table_name = "tenants"
+%s
+}
+`, endpoint, dataSource, options)
+}
+
+func testQueryDataSourceFake(t *testing.T) *fakeDynamoDB {
+	fake := newFakeDynamoDB(t)
+	fake.createTable("tenants", "tenant", "sk")
+	fake.putItem(t, "tenants", `{"tenant":{"S":"a"},"sk":{"S":"config"},"theme":{"S":"dark"}}`)
+	fake.putItem(t, "tenants", `{"tenant":{"S":"a"},"sk":{"S":"user#1"},"enabled":{"BOOL":true},"roles":{"SS":["b","a"]}}`)
+	fake.putItem(t, "tenants", `{"tenant":{"S":"a"},"sk":{"S":"user#2"},"enabled":{"BOOL":false}}`)
+	fake.putItem(t, "tenants", `{"tenant":{"S":"a"},"sk":{"S":"user#3"},"enabled":{"BOOL":true}}`)
+	fake.putItem(t, "tenants", `{"tenant":{"S":"b"},"sk":{"S":"user#1"},"enabled":{"BOOL":true}}`)
+	// Results span several pages unless a test sets limit.
+	fake.pageSize = 2
+	return fake
+}
+
+func TestQueryDataSource_basic(t *testing.T) {
+	fake := testQueryDataSourceFake(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		Steps: []resource.TestStep{
+			{
+				Config: testQueryDataSourceConfig(fake.URL, "query", `
+  key_condition_expression    = "tenant = :tenant AND begins_with(sk, :prefix)"
+  expression_attribute_values = jsonencode({ ":tenant" = "a", ":prefix" = "user#" })
+  set_format                  = "sorted"
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.json2dynamodb_query.test", "items.#", "3"),
+					resource.TestCheckResourceAttr("data.json2dynamodb_query.test", "items.0", `{"enabled":true,"roles":["a","b"],"sk":"user#1","tenant":"a"}`),
+					resource.TestCheckResourceAttr("data.json2dynamodb_query.test", "items.1", `{"enabled":false,"sk":"user#2","tenant":"a"}`),
+					resource.TestCheckResourceAttr("data.json2dynamodb_query.test", "items.2", `{"enabled":true,"sk":"user#3","tenant":"a"}`),
+					resource.TestCheckResourceAttr("data.json2dynamodb_query.test", "id", "tenants"),
+				),
+			},
+			{
+				Config: testQueryDataSourceConfig(fake.URL, "query", `
+  index_name                  = "by_tenant"
+  key_condition_expression    = "#tenant = :tenant"
+  filter_expression           = "enabled = :enabled"
+  projection_expression       = "sk"
+  expression_attribute_names  = { "#tenant" = "tenant" }
+  expression_attribute_values = jsonencode({ ":tenant" = "a", ":enabled" = true })
+  scan_index_forward          = false
+  limit                       = 2
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.json2dynamodb_query.test", "items.#", "2"),
+					resource.TestCheckResourceAttr("data.json2dynamodb_query.test", "items.0", `{"sk":"user#3"}`),
+					resource.TestCheckResourceAttr("data.json2dynamodb_query.test", "items.1", `{"sk":"user#1"}`),
+				),
+			},
+			{
+				Config: testQueryDataSourceConfig(fake.URL, "query", `
+  key_condition_expression    = "tenant = :tenant"
+  expression_attribute_values = jsonencode({ ":tenant" = "c" })
+`),
+				Check: resource.TestCheckResourceAttr("data.json2dynamodb_query.test", "items.#", "0"),
+			},
+			{
+				Config: testQueryDataSourceConfig(fake.URL, "query", `
+  key_condition_expression    = "tenant = :tenant"
+  expression_attribute_values = jsonencode({ ":tenant" = "a" })
+  limit                       = 0
+`),
+				ExpectError: regexp.MustCompile(`Expected limit to be at least 1, got: 0`),
+			},
+			{
+				Config: testQueryDataSourceConfig(fake.URL, "query", `
+  key_condition_expression    = "tenant = :tenant"
+  expression_attribute_values = jsonencode([":tenant"])
+`),
+				ExpectError: regexp.MustCompile(`(?s)expression_attribute_values = jsonencode.*A DynamoDB item must be a JSON object`),
+			},
+		},
+	})
+}
+
+func TestScanDataSource_basic(t *testing.T) {
+	fake := testQueryDataSourceFake(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		Steps: []resource.TestStep{
+			{
+				Config: testQueryDataSourceConfig(fake.URL, "scan", `
+  filter_expression           = "sk = :sk"
+  expression_attribute_values = jsonencode({ ":sk" = "user#1" })
+  projection_expression       = "tenant"
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.json2dynamodb_scan.test", "items.#", "2"),
+					resource.TestCheckResourceAttr("data.json2dynamodb_scan.test", "items.0", `{"tenant":"a"}`),
+					resource.TestCheckResourceAttr("data.json2dynamodb_scan.test", "items.1", `{"tenant":"b"}`),
+				),
+			},
+			{
+				Config: testQueryDataSourceConfig(fake.URL, "scan", `
+  segment               = 0
+  total_segments        = 2
+  projection_expression = "sk"
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.json2dynamodb_scan.test", "items.#", "3"),
+					resource.TestCheckResourceAttr("data.json2dynamodb_scan.test", "items.0", `{"sk":"config"}`),
+					resource.TestCheckResourceAttr("data.json2dynamodb_scan.test", "items.1", `{"sk":"user#2"}`),
+					resource.TestCheckResourceAttr("data.json2dynamodb_scan.test", "items.2", `{"sk":"user#1"}`),
+				),
+			},
+			{
+				Config: testQueryDataSourceConfig(fake.URL, "scan", `
+  limit = 3
+`),
+				Check: resource.TestCheckResourceAttr("data.json2dynamodb_scan.test", "items.#", "3"),
+			},
+			{
+				Config: testQueryDataSourceConfig(fake.URL, "scan", `
+  segment = 1
+`),
+				ExpectError: regexp.MustCompile(`segment and total_segments must be set together`),
+			},
+			{
+				Config: testQueryDataSourceConfig(fake.URL, "scan", `
+  segment        = 2
+  total_segments = 2
+`),
+				ExpectError: regexp.MustCompile(`Expected segment to be between 0 and 1, got: 2`),
+			},
+		},
+	})
+}
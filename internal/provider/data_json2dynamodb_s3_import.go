@@ -0,0 +1,176 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+
+	awstypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Input formats of the DynamoDB import from S3, matching the input_format
+// argument of aws_dynamodb_table.import_table.
+const (
+	s3ImportFormatDynamoDBJSON = "DYNAMODB_JSON"
+	s3ImportFormatIon          = "ION"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &JSON2DynamoDBS3ImportDataSource{}
+var _ datasource.DataSourceWithConfigure = &JSON2DynamoDBS3ImportDataSource{}
+
+func NewJSON2DynamoDBS3ImportDataSource() datasource.DataSource {
+	return &JSON2DynamoDBS3ImportDataSource{}
+}
+
+// JSON2DynamoDBS3ImportDataSource defines the data source implementation.
+type JSON2DynamoDBS3ImportDataSource struct {
+	specs *specCache
+}
+
+// JSON2DynamoDBS3ImportDataSourceModel describes the data source data model.
+type JSON2DynamoDBS3ImportDataSourceModel struct {
+	EncodeOptionsModel
+
+	JSON        types.String `tfsdk:"json"`
+	InputFormat types.String `tfsdk:"input_format"`
+	Content     types.String `tfsdk:"content"`
+	ItemCount   types.Int64  `tfsdk:"item_count"`
+	Id          types.String `tfsdk:"id"`
+}
+
+func (d *JSON2DynamoDBS3ImportDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_s3_import"
+}
+
+func (d *JSON2DynamoDBS3ImportDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := encodeOptionsAttributes()
+	maps.Copy(attributes, map[string]schema.Attribute{
+		"json": schema.StringAttribute{
+			MarkdownDescription: "JSON array of items, or JSON Lines with one item per line",
+			Required:            true,
+		},
+		"input_format": schema.StringAttribute{
+			MarkdownDescription: fmt.Sprintf("Format of `content`, either `%s` or `%s`. Defaults to `%s`", s3ImportFormatDynamoDBJSON, s3ImportFormatIon, s3ImportFormatDynamoDBJSON),
+			Optional:            true,
+		},
+		"content": schema.StringAttribute{
+			MarkdownDescription: "The items as an S3 import object, with one `Item` record per line",
+			Computed:            true,
+		},
+		"item_count": schema.Int64Attribute{
+			MarkdownDescription: "The number of items in `content`",
+			Computed:            true,
+		},
+		"id": schema.StringAttribute{
+			MarkdownDescription: "The ID of this data source",
+			Computed:            true,
+		},
+	})
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "JSON items into an object for the DynamoDB import from S3, in the `DYNAMODB_JSON` or `ION` format also written by DynamoDB exports to S3. Each item is validated against `spec` on its own.",
+
+		Attributes: attributes,
+	}
+}
+
+func (d *JSON2DynamoDBS3ImportDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	pd, diags := configuredProviderData(req.ProviderData)
+	resp.Diagnostics.Append(diags...)
+
+	if pd != nil {
+		d.specs = pd.specs
+	}
+}
+
+func (d *JSON2DynamoDBS3ImportDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JSON2DynamoDBS3ImportDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	format := data.InputFormat.ValueString()
+	switch format {
+	case "":
+		format = s3ImportFormatDynamoDBJSON
+	case s3ImportFormatDynamoDBJSON, s3ImportFormatIon:
+	default:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("input_format"),
+			"Invalid Input Format",
+			fmt.Sprintf("Expected one of %q or %q, got: %q.", s3ImportFormatDynamoDBJSON, s3ImportFormatIon, format),
+		)
+	}
+
+	opts, diags := data.encodeOptions(ctx, d.specs)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	encoder, diags := newItemEncoder(opts)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.setSpecDraft(encoder)
+
+	converted, diags := encoder.encodeJSONItems(data.JSON.ValueString())
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(data.setAppliedChanges(ctx, encoder)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var content string
+	var err error
+	switch format {
+	case s3ImportFormatIon:
+		content, err = SerializeIonItems(converted)
+	default:
+		content, err = serializeDynamoDBJSONItems(converted)
+	}
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("input_format"),
+			"S3 Import Serialization Failed",
+			fmt.Sprintf("The provider received an unexpected error while attempting to render the items in the S3 import format.\n\nError: %s", err),
+		)
+		return
+	}
+
+	data.Content = types.StringValue(content)
+	data.ItemCount = types.Int64Value(int64(len(converted)))
+	data.Id = types.StringValue("-")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// serializeDynamoDBJSONItems renders items in the DYNAMODB_JSON format of
+// the DynamoDB import from S3: one {"Item":{...}} record per line.
+func serializeDynamoDBJSONItems(items []map[string]awstypes.AttributeValue) (string, error) {
+	var sb strings.Builder
+	for _, item := range items {
+		b, err := SerializeAttributeMap(item)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString(`{"Item":`)
+		sb.Write(b)
+		sb.WriteString("}\n")
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+const testS3ImportDataSourceConfig_basic = `
+data "json2dynamodb_s3_import" "dynamodb_json" {
+  json = jsonencode([
+    { pk = "tenant#1", count = 1 },
+    { pk = "tenant#2", tags = ["a", "b"] },
+  ])
+
+  type_hints = { "/tags" = "SS" }
+}
+
+data "json2dynamodb_s3_import" "ion" {
+  json = <<EOF
+{"pk": "tenant#1", "count": 1, "price": 1.5E3, "blob": "aGVsbG8=", "none": null}
+{"pk": "tenant#2", "tags": ["a", "b"], "nested": {"ok": true, "list": [0.25]}}
+EOF
+
+  type_hints   = { "/tags" = "SS", "/blob" = "B" }
+  input_format = "ION"
+}
+
+output "dynamodb_json" {
+  value = data.json2dynamodb_s3_import.dynamodb_json.content
+}
+
+output "ion" {
+  value = data.json2dynamodb_s3_import.ion.content
+}
+`
+
+const testS3ImportDataSourceConfig_invalidFormat = `
+data "json2dynamodb_s3_import" "test" {
+  json         = jsonencode([{ pk = "a" }])
+  input_format = "CSV"
+}
+`
+
+func TestS3ImportDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		Steps: []resource.TestStep{
+			{
+				Config: testS3ImportDataSourceConfig_basic,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("dynamodb_json",
+						`{"Item":{"count":{"N":"1"},"pk":{"S":"tenant#1"}}}`+"\n"+
+							`{"Item":{"pk":{"S":"tenant#2"},"tags":{"SS":["a","b"]}}}`+"\n",
+					),
+					resource.TestCheckResourceAttr("data.json2dynamodb_s3_import.dynamodb_json", "item_count", "2"),
+					resource.TestCheckOutput("ion",
+						"$ion_1_0\n"+
+							`{Item:{"blob":{{aGVsbG8=}},"count":1.,"none":null,"pk":"tenant#1","price":1.5d3}}`+"\n"+
+							`{Item:{"nested":{"list":[0.25],"ok":true},"pk":"tenant#2","tags":$dynamodb_SS::["a","b"]}}`+"\n",
+					),
+				),
+			},
+			{
+				Config:      testS3ImportDataSourceConfig_invalidFormat,
+				ExpectError: regexp.MustCompile(`Invalid Input Format`),
+			},
+		},
+	})
+}
@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	awstypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// scanMaxTotalSegments is the maximum number of segments of a parallel scan.
+const scanMaxTotalSegments = 1000000
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &JSON2DynamoDBScanDataSource{}
+var _ datasource.DataSourceWithConfigure = &JSON2DynamoDBScanDataSource{}
+
+func NewJSON2DynamoDBScanDataSource() datasource.DataSource {
+	return &JSON2DynamoDBScanDataSource{}
+}
+
+// JSON2DynamoDBScanDataSource defines the data source implementation.
+type JSON2DynamoDBScanDataSource struct {
+	client *dynamoDBClient
+}
+
+// JSON2DynamoDBScanDataSourceModel describes the data source data model.
+type JSON2DynamoDBScanDataSourceModel struct {
+	ReadItemsModel
+
+	Segment       types.Int64 `tfsdk:"segment"`
+	TotalSegments types.Int64 `tfsdk:"total_segments"`
+}
+
+func (d *JSON2DynamoDBScanDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_scan"
+}
+
+func (d *JSON2DynamoDBScanDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := readItemsAttributes()
+	maps.Copy(attributes, map[string]schema.Attribute{
+		"segment": schema.Int64Attribute{
+			MarkdownDescription: "Segment of a parallel scan to read, from 0 to `total_segments` - 1. Requires `total_segments`.",
+			Optional:            true,
+		},
+		"total_segments": schema.Int64Attribute{
+			MarkdownDescription: fmt.Sprintf("Number of segments of a parallel scan, up to %d. Requires `segment`.", scanMaxTotalSegments),
+			Optional:            true,
+		},
+	})
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads all the items of a DynamoDB table or index with Scan, following every page of results, and returns them as plain JSON. Meant for small lookup tables; use `json2dynamodb_query` to read the items of one hash key.",
+
+		Attributes: attributes,
+	}
+}
+
+func (d *JSON2DynamoDBScanDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	pd, diags := configuredProviderData(req.ProviderData)
+	resp.Diagnostics.Append(diags...)
+
+	if pd != nil {
+		d.client = pd.dynamodb
+	}
+}
+
+func (d *JSON2DynamoDBScanDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JSON2DynamoDBScanDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	input, diags := data.input(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	var segment, totalSegments *int32
+	switch {
+	case data.Segment.IsNull() && data.TotalSegments.IsNull():
+	case data.Segment.IsNull() || data.TotalSegments.IsNull():
+		resp.Diagnostics.AddAttributeError(
+			path.Root("segment"),
+			"Incomplete Parallel Scan",
+			"segment and total_segments must be set together.",
+		)
+	case data.TotalSegments.ValueInt64() < 1 || data.TotalSegments.ValueInt64() > scanMaxTotalSegments:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("total_segments"),
+			"Invalid Total Segments",
+			fmt.Sprintf("Expected total_segments to be between 1 and %d, got: %d.", scanMaxTotalSegments, data.TotalSegments.ValueInt64()),
+		)
+	case data.Segment.ValueInt64() < 0 || data.Segment.ValueInt64() >= data.TotalSegments.ValueInt64():
+		resp.Diagnostics.AddAttributeError(
+			path.Root("segment"),
+			"Invalid Segment",
+			fmt.Sprintf("Expected segment to be between 0 and %d, got: %d.", data.TotalSegments.ValueInt64()-1, data.Segment.ValueInt64()),
+		)
+	default:
+		segment = aws.Int32(int32(data.Segment.ValueInt64()))
+		totalSegments = aws.Int32(int32(data.TotalSegments.ValueInt64()))
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := d.client.get(ctx, data.Endpoint.ValueString())
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(data.readItems(ctx, "Scan", func(ctx context.Context, startKey map[string]awstypes.AttributeValue, limit *int32) ([]map[string]awstypes.AttributeValue, map[string]awstypes.AttributeValue, error) {
+		out, err := client.Scan(ctx, &dynamodb.ScanInput{
+			TableName:                 input.TableName,
+			IndexName:                 input.IndexName,
+			FilterExpression:          input.FilterExpression,
+			ProjectionExpression:      input.ProjectionExpression,
+			ExpressionAttributeNames:  input.ExpressionAttributeNames,
+			ExpressionAttributeValues: input.ExpressionAttributeValues,
+			ConsistentRead:            input.ConsistentRead,
+			Segment:                   segment,
+			TotalSegments:             totalSegments,
+			ExclusiveStartKey:         startKey,
+			Limit:                     limit,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return out.Items, out.LastEvaluatedKey, nil
+	})...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
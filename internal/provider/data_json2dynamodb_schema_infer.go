@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &JSON2DynamoDBSchemaInferDataSource{}
+
+func NewJSON2DynamoDBSchemaInferDataSource() datasource.DataSource {
+	return &JSON2DynamoDBSchemaInferDataSource{}
+}
+
+// JSON2DynamoDBSchemaInferDataSource defines the data source implementation.
+type JSON2DynamoDBSchemaInferDataSource struct{}
+
+// JSON2DynamoDBSchemaInferDataSourceModel describes the data source data model.
+type JSON2DynamoDBSchemaInferDataSourceModel struct {
+	JSON          types.String         `tfsdk:"json"`
+	SchemaDialect types.String         `tfsdk:"schema_dialect"`
+	MaxEnumValues types.Int64          `tfsdk:"max_enum_values"`
+	Schema        jsontypes.Normalized `tfsdk:"schema"`
+	Id            types.String         `tfsdk:"id"`
+}
+
+func (d *JSON2DynamoDBSchemaInferDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_schema_infer"
+}
+
+func (d *JSON2DynamoDBSchemaInferDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Infers a JSON Schema from sample items, as a starting point for the `spec` argument of the other data sources. Review and tighten it by hand before relying on it.",
+
+		Attributes: map[string]schema.Attribute{
+			"json": schema.StringAttribute{
+				MarkdownDescription: "Sample items as a JSON array, or JSON Lines with one item per line",
+				Required:            true,
+			},
+			"schema_dialect": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("JSON Schema draft of the inferred schema: one of `%s`. Defaults to `%s`.", strings.Join(slices.Sorted(maps.Keys(inferDialects)), "`, `"), defaultInferDialect),
+				Optional:            true,
+			},
+			"max_enum_values": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("The most distinct values a string may take across the samples to be inferred as an `enum`, provided some value repeats. `0` disables enums. Defaults to `%d`.", defaultMaxEnumValues),
+				Optional:            true,
+			},
+			"schema": schema.StringAttribute{
+				MarkdownDescription: "The inferred JSON Schema. Properties present in every sample are `required`, arrays whose members all have one type get `items`, and strings that are all RFC 3339 date-times, UUIDs or base64 get `format` `date-time`, `uuid` or `byte`.",
+				Computed:            true,
+				CustomType:          jsontypes.NormalizedType{},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of this data source",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *JSON2DynamoDBSchemaInferDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JSON2DynamoDBSchemaInferDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	opts := inferOptions{
+		SchemaDialect: data.SchemaDialect.ValueString(),
+		MaxEnumValues: defaultMaxEnumValues,
+	}
+	if !data.MaxEnumValues.IsNull() {
+		if n := data.MaxEnumValues.ValueInt64(); n < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_enum_values"),
+				"Invalid Max Enum Values",
+				fmt.Sprintf("Expected a number of at least 0, got: %d.", n),
+			)
+			return
+		}
+		opts.MaxEnumValues = int(data.MaxEnumValues.ValueInt64())
+	}
+
+	result, diags := inferSchema(data.JSON.ValueString(), opts)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Schema = jsontypes.NewNormalizedValue(result)
+	data.Id = types.StringValue("-")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
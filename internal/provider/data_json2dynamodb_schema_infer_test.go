@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+const testSchemaInferDataSourceConfig_basic = `
+locals {
+  samples = [
+    {
+      id       = "0b8f6f4e-6a43-4c38-9f7c-1d0a6f3e2b11"
+      status   = "active"
+      count    = 1
+      price    = 2
+      created  = "2024-05-01T10:00:00Z"
+      avatar   = "aGVsbG8gd29ybGQ="
+      tags     = ["a", "b"]
+      mixed    = [1, "a"]
+      address  = { city = "Paris", zip = "75001" }
+      nickname = "bob"
+    },
+    {
+      id      = "5c1e2a77-2f36-4d3f-8a6b-0e9d4c7b8a22"
+      status  = "active"
+      count   = 2
+      price   = 2.5
+      created = "2024-05-02T11:30:00.5+02:00"
+      avatar  = "Zm9vYmFyMQ=="
+      tags    = []
+      mixed   = []
+      address = { city = "Lyon" }
+      note    = null
+    },
+    {
+      id      = "9d4a0c3b-7e21-4b5f-9c8d-2f1e0a6b5c33"
+      status  = "disabled"
+      count   = 3
+      price   = 3
+      created = "2024-05-03T12:00:00Z"
+      avatar  = "YWJjZA=="
+      tags    = ["c"]
+      mixed   = []
+      address = { city = "Paris" }
+      note    = "x"
+    },
+  ]
+}
+
+data "json2dynamodb_schema_infer" "test" {
+  json = jsonencode(local.samples)
+}
+
+data "json2dynamodb_schema_infer" "lines" {
+  json            = join("\n", [for s in local.samples : jsonencode({ status = s.status })])
+  schema_dialect  = "2020-12"
+  max_enum_values = 0
+}
+
+data "json2dynamodb_items" "test" {
+  json         = jsonencode(local.samples)
+  spec         = data.json2dynamodb_schema_infer.test.schema
+  schema_types = true
+}
+`
+
+func TestSchemaInferDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		Steps: []resource.TestStep{
+			{
+				Config: testSchemaInferDataSourceConfig_basic,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.json2dynamodb_schema_infer.test", "schema", `{"$schema":"http://json-schema.org/draft-07/schema#","properties":{"address":{"properties":{"city":{"enum":["Lyon","Paris"],"type":"string"},"zip":{"type":"string"}},"required":["city"],"type":"object"},"avatar":{"format":"byte","type":"string"},"count":{"type":"integer"},"created":{"format":"date-time","type":"string"},"id":{"format":"uuid","type":"string"},"mixed":{"type":"array"},"nickname":{"type":"string"},"note":{"type":["null","string"]},"price":{"type":"number"},"status":{"enum":["active","disabled"],"type":"string"},"tags":{"items":{"type":"string"},"type":"array"}},"required":["address","avatar","count","created","id","mixed","price","status","tags"],"type":"object"}`),
+					resource.TestCheckResourceAttr("data.json2dynamodb_schema_infer.lines", "schema", `{"$schema":"https://json-schema.org/draft/2020-12/schema","properties":{"status":{"type":"string"}},"required":["status"],"type":"object"}`),
+					resource.TestCheckResourceAttr("data.json2dynamodb_items.test", "results.0", `{"address":{"M":{"city":{"S":"Paris"},"zip":{"S":"75001"}}},"avatar":{"B":"aGVsbG8gd29ybGQ="},"count":{"N":"1"},"created":{"S":"2024-05-01T10:00:00Z"},"id":{"S":"0b8f6f4e-6a43-4c38-9f7c-1d0a6f3e2b11"},"mixed":{"L":[{"N":"1"},{"S":"a"}]},"nickname":{"S":"bob"},"price":{"N":"2"},"status":{"S":"active"},"tags":{"L":[{"S":"a"},{"S":"b"}]}}`),
+				),
+			},
+			{
+				Config: `
+data "json2dynamodb_schema_infer" "test" {
+  json = jsonencode([{ id = "a" }, ["b"]])
+}
+`,
+				ExpectError: regexp.MustCompile(`Item 1: A DynamoDB item must be a JSON object, got: array`),
+			},
+			{
+				Config: `
+data "json2dynamodb_schema_infer" "test" {
+  json           = jsonencode([{ id = "a" }])
+  schema_dialect = "draft-04"
+}
+`,
+				ExpectError: regexp.MustCompile(`Expected one of 2020-12, draft-07, got: "draft-04"`),
+			},
+		},
+	})
+}
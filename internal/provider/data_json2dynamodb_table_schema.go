@@ -0,0 +1,248 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &JSON2DynamoDBTableSchemaDataSource{}
+var _ datasource.DataSourceWithConfigure = &JSON2DynamoDBTableSchemaDataSource{}
+
+func NewJSON2DynamoDBTableSchemaDataSource() datasource.DataSource {
+	return &JSON2DynamoDBTableSchemaDataSource{}
+}
+
+// JSON2DynamoDBTableSchemaDataSource defines the data source implementation.
+type JSON2DynamoDBTableSchemaDataSource struct {
+	specs *specCache
+}
+
+// JSON2DynamoDBTableSchemaDataSourceModel describes the data source data model.
+type JSON2DynamoDBTableSchemaDataSourceModel struct {
+	Spec                   types.String               `tfsdk:"spec"`
+	SpecFile               types.String               `tfsdk:"spec_file"`
+	SpecDir                types.String               `tfsdk:"spec_dir"`
+	SchemaRef              types.String               `tfsdk:"schema_ref"`
+	HashKey                types.String               `tfsdk:"hash_key"`
+	RangeKey               types.String               `tfsdk:"range_key"`
+	TTLAttribute           types.String               `tfsdk:"ttl_attribute"`
+	Attributes             []TableAttributeModel      `tfsdk:"attributes"`
+	GlobalSecondaryIndexes []TableSecondaryIndexModel `tfsdk:"global_secondary_indexes"`
+	Id                     types.String               `tfsdk:"id"`
+}
+
+// TableAttributeModel describes a key attribute definition.
+type TableAttributeModel struct {
+	Name types.String `tfsdk:"name"`
+	Type types.String `tfsdk:"type"`
+}
+
+// TableSecondaryIndexModel describes a global secondary index definition.
+type TableSecondaryIndexModel struct {
+	Name             types.String `tfsdk:"name"`
+	HashKey          types.String `tfsdk:"hash_key"`
+	RangeKey         types.String `tfsdk:"range_key"`
+	ProjectionType   types.String `tfsdk:"projection_type"`
+	NonKeyAttributes types.List   `tfsdk:"non_key_attributes"`
+}
+
+func (d *JSON2DynamoDBTableSchemaDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_table_schema"
+}
+
+func (d *JSON2DynamoDBTableSchemaDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: fmt.Sprintf("Reads the key attributes, global secondary indexes and TTL attribute of a table from the vendor extensions of its item schema, for the `attribute`, `global_secondary_index` and `ttl` blocks of `aws_dynamodb_table`. On the top-level properties of the schema, `%s: %s` or `%s` makes a property a key of the table, `%s: %s` makes it the TTL attribute, and `%s` makes it a key of global secondary indexes. `%s` is an index name, for its hash key, an object with `name`, `key` (`%s`, the default, or `%s`), `projection_type` (`%s`, the default, `%s` or `%s`) and `non_key_attributes`, or a list of either. Key types come from `%s`, or from the type and format of the property: `string` is `S`, `format: byte` or `binary` strings are `B`, `integer` and `number` are `N`.", tableKeyExtension, keyRoleHash, keyRoleRange, tableTTLExtension, "true", tableGSIExtension, tableGSIExtension, keyRoleHash, keyRoleRange, projectionTypeAll, projectionTypeKeysOnly, projectionTypeInclude, schemaTypeExtension),
+
+		Attributes: map[string]schema.Attribute{
+			"spec": schema.StringAttribute{
+				MarkdownDescription: "JSON Schema of the items of the table, in JSON or YAML. With `schema_ref`, a whole OpenAPI 3.x document instead. Conflicts with `spec_file`.",
+				Optional:            true,
+			},
+			"spec_file": schema.StringAttribute{
+				MarkdownDescription: "Path of a file to read `spec` from, in JSON or YAML. Relative `$ref`s are resolved against the file.",
+				Optional:            true,
+			},
+			"spec_dir": schema.StringAttribute{
+				MarkdownDescription: "Directory a relative `spec_file` is read from. With `spec`, relative `$ref`s in it are resolved against this directory.",
+				Optional:            true,
+			},
+			"schema_ref": schema.StringAttribute{
+				MarkdownDescription: "Reference to the component schema of the items in the OpenAPI document in `spec`, e.g. `#/components/schemas/Order`.",
+				Optional:            true,
+			},
+			"hash_key": schema.StringAttribute{
+				MarkdownDescription: "Name of the hash (partition) key attribute of the table",
+				Computed:            true,
+			},
+			"range_key": schema.StringAttribute{
+				MarkdownDescription: "Name of the range (sort) key attribute of the table. Null without one.",
+				Computed:            true,
+			},
+			"ttl_attribute": schema.StringAttribute{
+				MarkdownDescription: "Name of the TTL attribute, which must be a number. Null without one.",
+				Computed:            true,
+			},
+			"attributes": schema.ListNestedAttribute{
+				MarkdownDescription: "The key attributes of the table and its indexes, sorted by name",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Name of the attribute",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: fmt.Sprintf("Type of the attribute: `%s`", strings.Join(keyTypes, "`, `")),
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"global_secondary_indexes": schema.ListNestedAttribute{
+				MarkdownDescription: "The global secondary indexes, sorted by name",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Name of the index",
+							Computed:            true,
+						},
+						"hash_key": schema.StringAttribute{
+							MarkdownDescription: "Name of the hash key attribute of the index",
+							Computed:            true,
+						},
+						"range_key": schema.StringAttribute{
+							MarkdownDescription: "Name of the range key attribute of the index. Null without one.",
+							Computed:            true,
+						},
+						"projection_type": schema.StringAttribute{
+							MarkdownDescription: fmt.Sprintf("Attributes projected into the index: `%s`", strings.Join(projectionTypes, "`, `")),
+							Computed:            true,
+						},
+						"non_key_attributes": schema.ListAttribute{
+							MarkdownDescription: fmt.Sprintf("Attributes projected into the index besides the keys, with `%s`. Null otherwise.", projectionTypeInclude),
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+					},
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of this data source",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *JSON2DynamoDBTableSchemaDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	pd, diags := configuredProviderData(req.ProviderData)
+	resp.Diagnostics.Append(diags...)
+
+	if pd != nil {
+		d.specs = pd.specs
+	}
+}
+
+func (d *JSON2DynamoDBTableSchemaDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JSON2DynamoDBTableSchemaDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	specPath := path.Root("spec")
+	if !data.SpecFile.IsNull() {
+		specPath = path.Root("spec_file")
+	}
+	if data.Spec.ValueString() == "" && data.SpecFile.ValueString() == "" {
+		resp.Diagnostics.AddAttributeError(
+			specPath,
+			"Missing JSON Spec",
+			"One of spec or spec_file must be set.",
+		)
+		return
+	}
+
+	// The encoder loads the spec as schema_types reads it, with its
+	// references resolved, which is where the vendor extensions are read.
+	encoder, diags := newItemEncoder(encodeOptions{
+		Spec:        data.Spec.ValueString(),
+		SpecFile:    data.SpecFile.ValueString(),
+		SpecDir:     data.SpecDir.ValueString(),
+		SchemaRef:   data.SchemaRef.ValueString(),
+		SchemaTypes: true,
+		Specs:       d.specs,
+	})
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	table, err := tableSchemaFromSpec(encoder.schema)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			specPath,
+			"Invalid Table Schema",
+			fmt.Sprintf("The provider could not read the table definition from the vendor extensions of the spec.\n\nError: %s", err),
+		)
+		return
+	}
+
+	data.HashKey = types.StringValue(table.HashKey)
+	data.RangeKey = optionalString(table.RangeKey)
+	data.TTLAttribute = optionalString(table.TTLAttribute)
+
+	data.Attributes = make([]TableAttributeModel, 0, len(table.Attributes))
+	for _, name := range slices.Sorted(maps.Keys(table.Attributes)) {
+		data.Attributes = append(data.Attributes, TableAttributeModel{
+			Name: types.StringValue(name),
+			Type: types.StringValue(table.Attributes[name]),
+		})
+	}
+
+	data.GlobalSecondaryIndexes = make([]TableSecondaryIndexModel, 0, len(table.Indexes))
+	for _, index := range table.Indexes {
+		nonKeyAttributes := types.ListNull(types.StringType)
+		if index.ProjectionType == projectionTypeInclude {
+			nonKeyAttributes, diags = types.ListValueFrom(ctx, types.StringType, index.NonKeyAttributes)
+			resp.Diagnostics.Append(diags...)
+		}
+		data.GlobalSecondaryIndexes = append(data.GlobalSecondaryIndexes, TableSecondaryIndexModel{
+			Name:             types.StringValue(index.Name),
+			HashKey:          types.StringValue(index.HashKey),
+			RangeKey:         optionalString(index.RangeKey),
+			ProjectionType:   types.StringValue(index.ProjectionType),
+			NonKeyAttributes: nonKeyAttributes,
+		})
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = types.StringValue("-")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// optionalString returns s as a string value, or null when it is empty.
+func optionalString(s string) types.String {
+	if s == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(s)
+}
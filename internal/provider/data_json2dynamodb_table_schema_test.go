@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+const testTableSchemaDataSourceConfig_basic = `
+data "json2dynamodb_table_schema" "test" {
+  spec = jsonencode({
+    type = "object"
+    allOf = [{
+      properties = {
+        expires_at = { type = "string", format = "date-time", "x-dynamodb-type" = "N", "x-dynamodb-ttl" = true }
+      }
+    }]
+    properties = {
+      pk     = { type = "string", "x-dynamodb-key" = "hash" }
+      sk     = { type = "integer", "x-dynamodb-key" = "range" }
+      status = { type = "string", "x-dynamodb-gsi" = ["by_status", { name = "by_owner", key = "range" }] }
+      owner  = { type = "string", format = "byte", "x-dynamodb-gsi" = { name = "by_owner", projection_type = "INCLUDE", non_key_attributes = ["status", "note"] } }
+      note   = { type = "string" }
+    }
+  })
+}
+
+data "json2dynamodb_table_schema" "openapi" {
+  schema_ref = "#/components/schemas/User"
+  spec       = <<EOF
+openapi: 3.0.3
+info: { title: Users, version: "1" }
+paths: {}
+components:
+  schemas:
+    Id:
+      type: string
+      x-dynamodb-key: hash
+    User:
+      type: object
+      properties:
+        id:
+          $ref: '#/components/schemas/Id'
+        email:
+          type: string
+          x-dynamodb-gsi: by_email
+EOF
+}
+`
+
+func testTableSchemaDataSourceConfig_invalid(properties string) string {
+	return `
+data "json2dynamodb_table_schema" "test" {
+  spec = jsonencode({ type = "object", properties = ` + properties + ` })
+}
+`
+}
+
+func TestTableSchemaDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		Steps: []resource.TestStep{
+			{
+				Config: testTableSchemaDataSourceConfig_basic,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.json2dynamodb_table_schema.test", "hash_key", "pk"),
+					resource.TestCheckResourceAttr("data.json2dynamodb_table_schema.test", "range_key", "sk"),
+					resource.TestCheckResourceAttr("data.json2dynamodb_table_schema.test", "ttl_attribute", "expires_at"),
+					resource.TestCheckResourceAttr("data.json2dynamodb_table_schema.test", "attributes.#", "4"),
+					resource.TestCheckResourceAttr("data.json2dynamodb_table_schema.test", "attributes.0.name", "owner"),
+					resource.TestCheckResourceAttr("data.json2dynamodb_table_schema.test", "attributes.0.type", "B"),
+					resource.TestCheckResourceAttr("data.json2dynamodb_table_schema.test", "attributes.1.name", "pk"),
+					resource.TestCheckResourceAttr("data.json2dynamodb_table_schema.test", "attributes.1.type", "S"),
+					resource.TestCheckResourceAttr("data.json2dynamodb_table_schema.test", "attributes.2.name", "sk"),
+					resource.TestCheckResourceAttr("data.json2dynamodb_table_schema.test", "attributes.2.type", "N"),
+					resource.TestCheckResourceAttr("data.json2dynamodb_table_schema.test", "attributes.3.name", "status"),
+					resource.TestCheckResourceAttr("data.json2dynamodb_table_schema.test", "global_secondary_indexes.#", "2"),
+					resource.TestCheckResourceAttr("data.json2dynamodb_table_schema.test", "global_secondary_indexes.0.name", "by_owner"),
+					resource.TestCheckResourceAttr("data.json2dynamodb_table_schema.test", "global_secondary_indexes.0.hash_key", "owner"),
+					resource.TestCheckResourceAttr("data.json2dynamodb_table_schema.test", "global_secondary_indexes.0.range_key", "status"),
+					resource.TestCheckResourceAttr("data.json2dynamodb_table_schema.test", "global_secondary_indexes.0.projection_type", "INCLUDE"),
+					resource.TestCheckResourceAttr("data.json2dynamodb_table_schema.test", "global_secondary_indexes.0.non_key_attributes.#", "2"),
+					resource.TestCheckResourceAttr("data.json2dynamodb_table_schema.test", "global_secondary_indexes.0.non_key_attributes.0", "note"),
+					resource.TestCheckResourceAttr("data.json2dynamodb_table_schema.test", "global_secondary_indexes.1.name", "by_status"),
+					resource.TestCheckResourceAttr("data.json2dynamodb_table_schema.test", "global_secondary_indexes.1.hash_key", "status"),
+					resource.TestCheckNoResourceAttr("data.json2dynamodb_table_schema.test", "global_secondary_indexes.1.range_key"),
+					resource.TestCheckResourceAttr("data.json2dynamodb_table_schema.test", "global_secondary_indexes.1.projection_type", "ALL"),
+					resource.TestCheckNoResourceAttr("data.json2dynamodb_table_schema.test", "global_secondary_indexes.1.non_key_attributes"),
+					resource.TestCheckResourceAttr("data.json2dynamodb_table_schema.openapi", "hash_key", "id"),
+					resource.TestCheckNoResourceAttr("data.json2dynamodb_table_schema.openapi", "range_key"),
+					resource.TestCheckNoResourceAttr("data.json2dynamodb_table_schema.openapi", "ttl_attribute"),
+					resource.TestCheckResourceAttr("data.json2dynamodb_table_schema.openapi", "global_secondary_indexes.0.hash_key", "email"),
+				),
+			},
+			{
+				Config:      testTableSchemaDataSourceConfig_invalid(`{ a = { type = "string", "x-dynamodb-key" = "hash" }, b = { type = "string", "x-dynamodb-key" = "hash" } }`),
+				ExpectError: regexp.MustCompile(`both "a" and "b" are declared as the hash key of the table`),
+			},
+			{
+				Config:      testTableSchemaDataSourceConfig_invalid(`{ a = { type = "string", "x-dynamodb-gsi" = { name = "idx", key = "range" } } }`),
+				ExpectError: regexp.MustCompile(`no property is declared as the hash key of the table`),
+			},
+			{
+				Config:      testTableSchemaDataSourceConfig_invalid(`{ a = { type = "string", "x-dynamodb-key" = "hash" }, b = { type = "string", "x-dynamodb-gsi" = { name = "idx", key = "range" } } }`),
+				ExpectError: regexp.MustCompile(`index "idx" has no hash key`),
+			},
+			{
+				Config:      testTableSchemaDataSourceConfig_invalid(`{ a = { type = "boolean", "x-dynamodb-key" = "hash" } }`),
+				ExpectError: regexp.MustCompile(`key attribute "a": expected type string, integer or number`),
+			},
+			{
+				Config:      testTableSchemaDataSourceConfig_invalid(`{ a = { type = "string", "x-dynamodb-key" = "hash" }, t = { type = "string", "x-dynamodb-ttl" = true } }`),
+				ExpectError: regexp.MustCompile(`TTL attribute "t" must be a number of epoch seconds`),
+			},
+			{
+				Config:      `data "json2dynamodb_table_schema" "test" {}`,
+				ExpectError: regexp.MustCompile(`One of spec or spec_file must be set`),
+			},
+		},
+	})
+}
@@ -2,6 +2,10 @@ package provider
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -171,6 +175,907 @@ func TestDataSource_basic(t *testing.T) {
 					return nil
 				},
 			},
+			{
+				Config: `
+data "json2dynamodb" "test" {
+  json = jsonencode([{ pk = "tenant#1" }])
+}
+`,
+				ExpectError: regexp.MustCompile(`got: array\. Use the\s+json2dynamodb_items data source`),
+			},
+		},
+	})
+}
+
+const testDataSourceConfig_numbers = `
+data "json2dynamodb" "test" {
+  json = <<EOF
+{"id": 1234567890123456789012, "amount": 0.1000000000000000055511151231257827, "tiny": 1E-130}
+EOF
+}
+
+output "ddbjson" {
+  value = data.json2dynamodb.test.result
+}
+`
+
+const testDataSourceConfig_numbersTooPrecise = `
+data "json2dynamodb" "test" {
+  json = <<EOF
+{"id": 123456789012345678901234567890123456789}
+EOF
+}
+`
+
+func TestDataSource_numbers(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceConfig_numbers,
+				Check: resource.TestCheckOutput("ddbjson",
+					`{"amount":{"N":"0.1000000000000000055511151231257827"},"id":{"N":"1234567890123456789012"},"tiny":{"N":"1E-130"}}`,
+				),
+			},
+			{
+				Config:      testDataSourceConfig_numbersTooPrecise,
+				ExpectError: regexp.MustCompile(`Invalid DynamoDB Number`),
+			},
+		},
+	})
+}
+
+const testDataSourceConfig_typeHints = `
+data "json2dynamodb" "test" {
+  json = jsonencode({
+    tags    = ["a", "b"]
+    ports   = [80, "443"]
+    account = "123456789012"
+    zip     = 12345
+    blob    = "aGVsbG8="
+    note    = ""
+    removed = null
+    items = [
+      { blob = ["aGVsbG8=", "d29ybGQ="] },
+    ]
+  })
+
+  type_hints = {
+    "/tags"         = "SS"
+    "/ports"        = "NS"
+    "/account"      = "N"
+    "/zip"          = "S"
+    "/blob"         = "B"
+    "/items/*/blob" = "BS"
+    "/note"         = "NULL"
+    "/removed"      = "NULL"
+  }
+}
+
+output "ddbjson" {
+  value = data.json2dynamodb.test.result
+}
+`
+
+const testDataSourceConfig_typeHintsDuplicate = `
+data "json2dynamodb" "test" {
+  json       = jsonencode({ tags = ["a", "a"] })
+  type_hints = { "/tags" = "SS" }
+}
+`
+
+func TestDataSource_typeHints(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceConfig_typeHints,
+				Check: resource.TestCheckOutput("ddbjson",
+					`{"account":{"N":"123456789012"},"blob":{"B":"aGVsbG8="},"items":{"L":[{"M":{"blob":{"BS":["aGVsbG8=","d29ybGQ="]}}}]},"note":{"NULL":true},"ports":{"NS":["80","443"]},"removed":{"NULL":true},"tags":{"SS":["a","b"]},"zip":{"S":"12345"}}`,
+				),
+			},
+			{
+				Config:      testDataSourceConfig_typeHintsDuplicate,
+				ExpectError: regexp.MustCompile(`duplicate SS member "a"`),
+			},
+		},
+	})
+}
+
+const testDataSourceConfig_schemaTypes = `
+data "json2dynamodb" "test" {
+  json = jsonencode({
+    tags       = ["a", "b"]
+    scores     = [1, 2.5]
+    avatar     = "aGVsbG8="
+    created_at = "2024-01-02T03:04:05Z"
+    updated_at = "2024-01-02T03:04:05.5Z"
+    zip        = "12345"
+    address    = { lines = ["1 Main St"] }
+  })
+
+  spec = jsonencode({
+    type = "object"
+    properties = {
+      tags       = { type = "array", uniqueItems = true, items = { type = "string" } }
+      scores     = { type = "array", uniqueItems = true, items = { type = "number" } }
+      avatar     = { type = "string", format = "byte" }
+      created_at = { type = "string", format = "date-time" }
+      updated_at = { type = "string", format = "date-time", "x-dynamodb-type" = "N" }
+      zip        = { type = "string", "x-dynamodb-type" = "N" }
+      address = {
+        type       = "object"
+        properties = { lines = { type = "array", items = { type = "string" } } }
+      }
+    }
+  })
+
+  schema_types = true
+}
+
+output "ddbjson" {
+  value = data.json2dynamodb.test.result
+}
+`
+
+func TestDataSource_schemaTypes(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceConfig_schemaTypes,
+				Check: resource.TestCheckOutput("ddbjson",
+					`{"address":{"M":{"lines":{"L":[{"S":"1 Main St"}]}}},"avatar":{"B":"aGVsbG8="},"created_at":{"S":"2024-01-02T03:04:05Z"},"scores":{"NS":["1","2.5"]},"tags":{"SS":["a","b"]},"updated_at":{"N":"1704164645.5"},"zip":{"N":"12345"}}`,
+				),
+			},
+			{
+				Config: `
+data "json2dynamodb" "test" {
+  json             = jsonencode({ created_at = "2024-01-02T03:04:05Z" })
+  epoch_date_times = true
+}
+`,
+				ExpectError: regexp.MustCompile(`Encoding date-time strings as epoch seconds requires schema_types`),
+			},
+		},
+	})
+}
+
+const testDataSourceConfig_partiQL = `
+data "json2dynamodb" "test" {
+  json = jsonencode({
+    pk     = "tenant#1"
+    sk     = "it's"
+    count  = 3
+    tags   = ["a", "b"]
+    blob   = "aGVsbG8="
+    nested = { ok = true, none = null, list = [1, "x"] }
+  })
+
+  type_hints = { "/tags" = "SS", "/blob" = "B" }
+
+  table_name             = "seed"
+  partiql_key_attributes = ["pk", "sk"]
+}
+`
+
+const testDataSourceConfig_partiQLMissingKey = `
+data "json2dynamodb" "test" {
+  json                   = jsonencode({ pk = "tenant#1", name = "one" })
+  table_name             = "seed"
+  partiql_key_attributes = ["pk", "sk"]
+}
+`
+
+func TestDataSource_partiQL(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceConfig_partiQL,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.json2dynamodb.test", "partiql_insert",
+						`INSERT INTO "seed" VALUE {'blob': ?, 'count': 3, 'nested': {'list': [1, 'x'], 'none': NULL, 'ok': true}, 'pk': 'tenant#1', 'sk': 'it''s', 'tags': <<'a', 'b'>>}`,
+					),
+					resource.TestCheckResourceAttr("data.json2dynamodb.test", "partiql_insert_parameters", `[{"B":"aGVsbG8="}]`),
+					resource.TestCheckResourceAttr("data.json2dynamodb.test", "partiql_update",
+						`UPDATE "seed" SET "blob"=? SET "count"=? SET "nested"=? SET "tags"=? WHERE "pk"=? AND "sk"=?`,
+					),
+					resource.TestCheckResourceAttr("data.json2dynamodb.test", "partiql_update_parameters",
+						`[{"B":"aGVsbG8="},{"N":"3"},{"M":{"list":{"L":[{"N":"1"},{"S":"x"}]},"none":{"NULL":true},"ok":{"BOOL":true}}},{"SS":["a","b"]},{"S":"tenant#1"},{"S":"it's"}]`,
+					),
+				),
+			},
+			{
+				Config:      testDataSourceConfig_partiQLMissingKey,
+				ExpectError: regexp.MustCompile(`key attribute "sk" is missing from the item`),
+			},
+		},
+	})
+}
+
+const testDataSourceConfig_itemSize = `
+data "json2dynamodb" "test" {
+  json = jsonencode({
+    pk     = "tenant#1"
+    count  = 123
+    ok     = true
+    tags   = ["a", "bb"]
+    nested = { x = 1 }
+  })
+
+  type_hints = { "/tags" = "SS" }
+}
+
+data "json2dynamodb" "large" {
+  json             = jsonencode({ blob = join("", [for i in range(1000) : format("%420s", "x")]) })
+  limit_violations = "warn"
+}
+`
+
+var testDataSourceConfig_itemTooDeep = fmt.Sprintf(`
+data "json2dynamodb" "test" {
+  json = <<EOF
+{"pk": "a", "deep": %s%s}
+EOF
+}
+`, strings.Repeat("[", 33), strings.Repeat("]", 33))
+
+func TestDataSource_itemLimits(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceConfig_itemSize,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.json2dynamodb.test", "item_size_bytes", "41"),
+					resource.TestCheckResourceAttr("data.json2dynamodb.test", "write_capacity_units", "1"),
+					resource.TestCheckResourceAttr("data.json2dynamodb.test", "read_capacity_units", "1"),
+					resource.TestCheckResourceAttr("data.json2dynamodb.large", "item_size_bytes", "420004"),
+					resource.TestCheckResourceAttr("data.json2dynamodb.large", "write_capacity_units", "411"),
+					resource.TestCheckResourceAttr("data.json2dynamodb.large", "read_capacity_units", "103"),
+				),
+			},
+			{
+				Config:      testDataSourceConfig_itemTooDeep,
+				ExpectError: regexp.MustCompile(`/deep(/0){32}:\s+nesting\s+is\s+deeper\s+than\s+the\s+32\s+level\s+DynamoDB\s+limit`),
+			},
+		},
+	})
+}
+
+const testDataSourceConfig_keySchema = `
+data "json2dynamodb" "test" {
+  json = jsonencode({ pk = "tenant#1", sk = 42, gsi_pk = "status#active", name = "one" })
+
+  key_schema {
+    hash_key       = "pk"
+    range_key      = "sk"
+    range_key_type = "N"
+
+    global_secondary_index {
+      name     = "by_status"
+      hash_key = "gsi_pk"
+    }
+
+    local_secondary_index {
+      name      = "by_created"
+      range_key = "created_at"
+    }
+  }
+}
+`
+
+const testDataSourceConfig_keySchemaMissing = `
+data "json2dynamodb" "test" {
+  json = jsonencode({ pk = "tenant#1", name = "one" })
+
+  key_schema {
+    hash_key  = "pk"
+    range_key = "sk"
+  }
+}
+`
+
+const testDataSourceConfig_keySchemaWrongType = `
+data "json2dynamodb" "test" {
+  json = jsonencode({ pk = "tenant#1", gsi_pk = 1 })
+
+  key_schema {
+    hash_key = "pk"
+
+    global_secondary_index {
+      name     = "by_status"
+      hash_key = "gsi_pk"
+    }
+  }
+}
+`
+
+const testDataSourceConfig_keySchemaTooLarge = `
+data "json2dynamodb" "test" {
+  json = jsonencode({ pk = "tenant#1", sk = format("%1025s", "x") })
+
+  key_schema {
+    hash_key  = "pk"
+    range_key = "sk"
+  }
+}
+`
+
+func TestDataSource_keySchema(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceConfig_keySchema,
+				Check:  resource.TestCheckResourceAttr("data.json2dynamodb.test", "key", `{"pk":{"S":"tenant#1"},"sk":{"N":"42"}}`),
+			},
+			{
+				Config:      testDataSourceConfig_keySchemaMissing,
+				ExpectError: regexp.MustCompile(`The item has no range_key attribute "sk"`),
+			},
+			{
+				Config:      testDataSourceConfig_keySchemaWrongType,
+				ExpectError: regexp.MustCompile(`Key attribute "gsi_pk": expected type S, got N`),
+			},
+			{
+				Config:      testDataSourceConfig_keySchemaTooLarge,
+				ExpectError: regexp.MustCompile(`range_key attribute "sk" is 1025 bytes, over\s+the\s+1024\s+byte\s+DynamoDB\s+limit`),
+			},
+		},
+	})
+}
+
+const testDataSourceConfig_specDrafts = `
+locals {
+  spec_2020 = jsonencode({
+    "$schema" = "https://json-schema.org/draft/2020-12/schema"
+    type      = "object"
+    properties = {
+      kind  = { enum = ["user", "group"] }
+      email = { type = "string" }
+      owner = { type = "string" }
+      v     = { const = 1 }
+    }
+    "if"              = { properties = { kind = { const = "user" } } }
+    "then"            = { required = ["email"] }
+    dependentRequired = { owner = ["kind"] }
+    unevaluatedProperties = false
+  })
+}
+
+data "json2dynamodb" "draft2020" {
+  json = jsonencode({ kind = "user", email = "a@example.com", v = 1 })
+  spec = local.spec_2020
+}
+
+data "json2dynamodb" "dialect" {
+  json           = jsonencode({ id = "a" })
+  spec           = jsonencode({ type = "object" })
+  schema_dialect = "draft-07"
+}
+
+data "json2dynamodb" "default" {
+  json = jsonencode({ id = "a" })
+  spec = jsonencode({ type = "object" })
+}
+`
+
+func testDataSourceConfig_specDraftsInvalid(json string) string {
+	return testDataSourceConfig_specDrafts + fmt.Sprintf(`
+data "json2dynamodb" "invalid" {
+  json = jsonencode(%s)
+  spec = local.spec_2020
+}
+`, json)
+}
+
+func TestDataSource_specDrafts(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceConfig_specDrafts,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.json2dynamodb.draft2020", "spec_draft", "2020-12"),
+					resource.TestCheckResourceAttr("data.json2dynamodb.dialect", "spec_draft", "draft-07"),
+					resource.TestCheckResourceAttr("data.json2dynamodb.default", "spec_draft", "draft-04"),
+				),
+			},
+			{
+				Config:      testDataSourceConfig_specDraftsInvalid(`{ kind = "user" }`),
+				ExpectError: regexp.MustCompile(`missing property 'email'`),
+			},
+			{
+				Config:      testDataSourceConfig_specDraftsInvalid(`{ owner = "a" }`),
+				ExpectError: regexp.MustCompile(`properties 'kind' required, if 'owner' exists`),
+			},
+			{
+				Config:      testDataSourceConfig_specDraftsInvalid(`{ kind = "group", extra = 1 }`),
+				ExpectError: regexp.MustCompile(`at '/extra': false schema`),
+			},
+			{
+				Config:      testDataSourceConfig_specDraftsInvalid(`{ kind = "group", v = 2 }`),
+				ExpectError: regexp.MustCompile(`value must be 1`),
+			},
+		},
+	})
+}
+
+const testDataSourceConfig_openAPI = `
+locals {
+  openapi = <<-YAML
+    openapi: 3.0.3
+    info:
+      title: Orders
+      version: "1"
+    paths: {}
+    components:
+      schemas:
+        Order:
+          type: object
+          required: [id, address]
+          properties:
+            id:
+              type: string
+              readOnly: true
+            secret:
+              type: string
+              writeOnly: true
+            note:
+              type: string
+              nullable: true
+            tags:
+              type: array
+              uniqueItems: true
+              items:
+                type: string
+            address:
+              $ref: "#/components/schemas/Address"
+            pet:
+              $ref: "#/components/schemas/Pet"
+        Address:
+          type: object
+          required: [city]
+          properties:
+            city:
+              type: string
+        Pet:
+          oneOf:
+            - $ref: "#/components/schemas/Cat"
+            - $ref: "#/components/schemas/Dog"
+          discriminator:
+            propertyName: petType
+        Cat:
+          type: object
+          required: [petType, lives]
+          properties:
+            petType:
+              type: string
+            lives:
+              type: integer
+        Dog:
+          type: object
+          required: [petType, bark]
+          properties:
+            petType:
+              type: string
+            bark:
+              type: boolean
+    YAML
+}
+
+data "json2dynamodb" "test" {
+  json = jsonencode({
+    id      = "o-1"
+    note    = null
+    tags    = ["a", "b"]
+    address = { city = "Oslo" }
+    pet     = { petType = "Cat", lives = 9 }
+  })
+  spec              = local.openapi
+  schema_ref        = "#/components/schemas/Order"
+  openapi_direction = "response"
+  schema_types      = true
+}
+`
+
+func testDataSourceConfig_openAPIInvalid(json, schemaRef, direction string) string {
+	return testDataSourceConfig_openAPI + fmt.Sprintf(`
+data "json2dynamodb" "invalid" {
+  json              = jsonencode(%s)
+  spec              = local.openapi
+  schema_ref        = %q
+  openapi_direction = %q
+}
+`, json, schemaRef, direction)
+}
+
+func TestDataSource_openAPI(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceConfig_openAPI,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.json2dynamodb.test", "spec_draft", "openapi-3.0.3"),
+					resource.TestCheckResourceAttr("data.json2dynamodb.test", "result", `{"address":{"M":{"city":{"S":"Oslo"}}},"id":{"S":"o-1"},"note":{"NULL":true},"pet":{"M":{"lives":{"N":"9"},"petType":{"S":"Cat"}}},"tags":{"SS":["a","b"]}}`),
+				),
+			},
+			{
+				Config:      testDataSourceConfig_openAPIInvalid(`{ id = "o-1", address = {} }`, "#/components/schemas/Order", "response"),
+				ExpectError: regexp.MustCompile(`at '/address/city': property "city" is missing`),
+			},
+			{
+				Config:      testDataSourceConfig_openAPIInvalid(`{ id = "o-1", address = { city = "Oslo" } }`, "#/components/schemas/Order", "request"),
+				ExpectError: regexp.MustCompile(`readOnly property "id" in request`),
+			},
+			{
+				Config:      testDataSourceConfig_openAPIInvalid(`{ id = "o-1", secret = "s", address = { city = "Oslo" } }`, "#/components/schemas/Order", "response"),
+				ExpectError: regexp.MustCompile(`writeOnly property "secret" in response`),
+			},
+			{
+				Config:      testDataSourceConfig_openAPIInvalid(`{ petType = "Dog", lives = 9 }`, "#/components/schemas/Pet", "request"),
+				ExpectError: regexp.MustCompile(`property "bark" is missing`),
+			},
+			{
+				Config:      testDataSourceConfig_openAPIInvalid(`{ city = "Oslo" }`, "#/components/schemas/Missing", "request"),
+				ExpectError: regexp.MustCompile(`the document has no component schema "Missing"`),
+			},
+		},
+	})
+}
+
+// testSpecFiles are the spec documents of TestDataSource_specFiles.
+var testSpecFiles = map[string]string{
+	"order.json": `{
+  "type": "object",
+  "required": ["id", "address"],
+  "properties": {
+    "id": { "$ref": "types.yaml#/definitions/id" },
+    "address": { "$ref": "common.json#/definitions/address" },
+    "tags": { "type": "array", "uniqueItems": true, "items": { "type": "string" } }
+  }
+}`,
+	"common.json": `{
+  "definitions": {
+    "address": {
+      "type": "object",
+      "required": ["city"],
+      "properties": { "city": { "type": "string" } }
+    }
+  }
+}`,
+	"types.yaml": `definitions:
+  id:
+    type: string
+    minLength: 1
+`,
+	"cycle.json": `{
+  "$ref": "#/definitions/a",
+  "definitions": {
+    "a": { "$ref": "cycle2.json#/definitions/b" }
+  }
+}`,
+	"cycle2.json": `{
+  "definitions": {
+    "b": { "$ref": "cycle.json#/definitions/a" }
+  }
+}`,
+	"remote.json": `{ "$ref": "https://example.com/schemas/order.json" }`,
+	"api.yaml": `openapi: 3.0.3
+info:
+  title: Orders
+  version: "1"
+paths: {}
+components:
+  schemas:
+    Order:
+      type: object
+      required: [address]
+      properties:
+        address:
+          $ref: "components.yaml#/Address"
+`,
+	"components.yaml": `Address:
+  type: object
+  required: [city]
+  properties:
+    city:
+      type: string
+`,
+}
+
+func testDataSourceConfig_specFiles(dir string) string {
+	return fmt.Sprintf(`
+data "json2dynamodb" "file" {
+  json = jsonencode({ id = "o-1", address = { city = "Oslo" }, tags = ["a"] })
+  spec_file    = "order.json"
+  spec_dir     = %[1]q
+  schema_types = true
+}
+
+data "json2dynamodb" "inline" {
+  json     = jsonencode({ city = "Oslo" })
+  spec     = jsonencode({ "$ref" = "common.json#/definitions/address" })
+  spec_dir = %[1]q
+}
+
+data "json2dynamodb" "openapi" {
+  json       = jsonencode({ address = { city = "Oslo" } })
+  spec_file  = %[2]q
+  schema_ref = "#/components/schemas/Order"
+}
+`, dir, dir+"/api.yaml")
+}
+
+func testDataSourceConfig_specFilesInvalid(dir, json, specFile string) string {
+	return testDataSourceConfig_specFiles(dir) + fmt.Sprintf(`
+data "json2dynamodb" "invalid" {
+  json      = jsonencode(%s)
+  spec_file = %q
+  spec_dir  = %q
+}
+`, json, specFile, dir)
+}
+
+func TestDataSource_specFiles(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range testSpecFiles {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceConfig_specFiles(dir),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.json2dynamodb.file", "result", `{"address":{"M":{"city":{"S":"Oslo"}}},"id":{"S":"o-1"},"tags":{"SS":["a"]}}`),
+					resource.TestCheckResourceAttr("data.json2dynamodb.file", "spec_draft", "draft-04"),
+					resource.TestCheckResourceAttr("data.json2dynamodb.inline", "result", `{"city":{"S":"Oslo"}}`),
+					resource.TestCheckResourceAttr("data.json2dynamodb.openapi", "spec_draft", "openapi-3.0.3"),
+				),
+			},
+			{
+				Config:      testDataSourceConfig_specFilesInvalid(dir, `{ id = "o-1", address = {} }`, "order.json"),
+				ExpectError: regexp.MustCompile(`missing property 'city'`),
+			},
+			{
+				Config:      testDataSourceConfig_specFilesInvalid(dir, `{ id = "", address = { city = "Oslo" } }`, "order.json"),
+				ExpectError: regexp.MustCompile(`minLength: got 0, want 1`),
+			},
+			{
+				Config:      testDataSourceConfig_specFilesInvalid(dir, `{}`, "cycle.json"),
+				ExpectError: regexp.MustCompile(`\$ref\s+cycle:`),
+			},
+			{
+				Config:      testDataSourceConfig_specFilesInvalid(dir, `{}`, "remote.json"),
+				ExpectError: regexp.MustCompile(`remote\s+references\s+are\s+disabled`),
+			},
+			{
+				Config:      testDataSourceConfig_specFilesInvalid(dir, `{}`, "missing.json"),
+				ExpectError: regexp.MustCompile(`no\s+such\s+file`),
+			},
+			{
+				Config: `
+data "json2dynamodb" "invalid" {
+  json = jsonencode({ city = "Oslo" })
+  spec = jsonencode({ "$ref" = "common.json#/definitions/address" })
+}
+`,
+				ExpectError: regexp.MustCompile(`require\s+spec_file\s+or\s+spec_dir`),
+			},
+		},
+	})
+}
+
+const testDataSourceConfig_specViolations = `
+data "json2dynamodb" "test" {
+  json = jsonencode({ for i in range(25) : "p${i}" => "x" })
+  spec = jsonencode({
+    type       = "object"
+    properties = { for i in range(25) : "p${i}" => { type = "integer" } }
+  })
+}
+`
+
+const testDataSourceConfig_specViolationsAnyOf = `
+data "json2dynamodb" "test" {
+  json = jsonencode({ id = true })
+  spec = jsonencode({
+    type       = "object"
+    properties = { id = { anyOf = [{ type = "string" }, { type = "integer" }] } }
+  })
+}
+`
+
+func TestDataSource_specViolations(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		Steps: []resource.TestStep{
+			{
+				Config:      testDataSourceConfig_specViolations,
+				ExpectError: regexp.MustCompile(`The item violates the spec in 25 places. Only the first 20 are\s+reported.`),
+			},
+			{
+				Config:      testDataSourceConfig_specViolations,
+				ExpectError: regexp.MustCompile(`at '/p0': got string, want integer\s+Keyword: type\s+Schema: urn:json2dynamodb:spec#/properties/p0/type\s+Value: "x"`),
+			},
+			{
+				Config:      testDataSourceConfig_specViolationsAnyOf,
+				ExpectError: regexp.MustCompile(`at '/id': 'anyOf' failed \(at '/id': got boolean, want string; at '/id': got\s+boolean, want integer\)\s+Keyword: anyOf`),
+			},
+		},
+	})
+}
+
+const testDataSourceConfig_validationSeverity = `
+locals {
+  strict = jsonencode({
+    type                 = "object"
+    required             = ["id"]
+    additionalProperties = false
+    properties = {
+      id     = { type = "string" }
+      legacy = { type = "object", properties = { count = { type = "integer" } } }
+    }
+  })
+}
+
+data "json2dynamodb" "warn" {
+  json            = jsonencode({ id = 1 })
+  spec            = local.strict
+  validation_mode = "warn"
+}
+
+data "json2dynamodb" "off" {
+  json            = jsonencode({ id = 1 })
+  spec            = local.strict
+  validation_mode = "off"
+}
+
+data "json2dynamodb" "overrides" {
+  json = jsonencode({ id = "a", extra = true, legacy = { count = "1" } })
+  spec = local.strict
+  validation_severity = {
+    additionalProperties = "warn"
+    "/legacy"            = "off"
+  }
+}
+`
+
+func testDataSourceConfig_validationSeverityInvalid(json, severity string) string {
+	return testDataSourceConfig_validationSeverity + fmt.Sprintf(`
+data "json2dynamodb" "invalid" {
+  json                = jsonencode(%s)
+  spec                = local.strict
+  validation_mode     = "warn"
+  validation_severity = %s
+}
+`, json, severity)
+}
+
+func TestDataSource_validationSeverity(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceConfig_validationSeverity,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.json2dynamodb.warn", "result", `{"id":{"N":"1"}}`),
+					resource.TestCheckResourceAttr("data.json2dynamodb.off", "result", `{"id":{"N":"1"}}`),
+					resource.TestCheckResourceAttr("data.json2dynamodb.overrides", "result", `{"extra":{"BOOL":true},"id":{"S":"a"},"legacy":{"M":{"count":{"S":"1"}}}}`),
+				),
+			},
+			{
+				Config:      testDataSourceConfig_validationSeverityInvalid(`{ extra = true }`, `{ required = "error" }`),
+				ExpectError: regexp.MustCompile(`at '': missing property 'id'`),
+			},
+			{
+				Config:      testDataSourceConfig_validationSeverityInvalid(`{ id = "a", legacy = { count = "1" } }`, `{ "/legacy/count" = "error", "/legacy" = "off" }`),
+				ExpectError: regexp.MustCompile(`at '/legacy/count': got string, want integer`),
+			},
+			{
+				Config:      testDataSourceConfig_validationSeverityInvalid(`{ id = "a" }`, `{ required = "fatal" }`),
+				ExpectError: regexp.MustCompile(`severity of "required" is "fatal"`),
+			},
+		},
+	})
+}
+
+const testDataSourceConfig_applyDefaults = `
+locals {
+  order = jsonencode({
+    type     = "object"
+    required = ["id", "count", "status"]
+    properties = {
+      id       = { type = "string" }
+      count    = { type = "integer" }
+      price    = { type = "number" }
+      gift     = { type = "boolean" }
+      code     = { type = ["string", "integer"] }
+      status   = { type = "string", default = "active" }
+      shipping = {
+        type    = "object"
+        default = {}
+        properties = {
+          method = { type = "string", default = "standard" }
+          days   = { type = "integer", default = 3 }
+        }
+      }
+      tags = { type = "array", items = { type = "integer" } }
+    }
+  })
+}
+
+data "json2dynamodb" "test" {
+  json           = jsonencode({ id = "a", count = "42", price = "9.5", gift = "true", code = "7", tags = ["1", 2] })
+  spec           = local.order
+  apply_defaults = true
+  coerce_types   = true
+}
+
+data "json2dynamodb" "plain" {
+  json = jsonencode({ id = "a", count = 1, status = "new" })
+  spec = local.order
+}
+`
+
+func TestDataSource_applyDefaults(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceConfig_applyDefaults,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.json2dynamodb.test", "result", `{"code":{"S":"7"},"count":{"N":"42"},"gift":{"BOOL":true},"id":{"S":"a"},"price":{"N":"9.5"},"shipping":{"M":{"days":{"N":"3"},"method":{"S":"standard"}}},"status":{"S":"active"},"tags":{"L":[{"N":"1"},{"N":"2"}]}}`),
+					resource.TestCheckResourceAttr("data.json2dynamodb.test", "applied_changes.#", "8"),
+					resource.TestCheckResourceAttr("data.json2dynamodb.test", "applied_changes.0", `/shipping: set default {}`),
+					resource.TestCheckResourceAttr("data.json2dynamodb.test", "applied_changes.1", `/status: set default "active"`),
+					resource.TestCheckResourceAttr("data.json2dynamodb.test", "applied_changes.2", `/count: coerced "42" to 42`),
+					resource.TestCheckResourceAttr("data.json2dynamodb.test", "applied_changes.3", `/gift: coerced "true" to true`),
+					resource.TestCheckResourceAttr("data.json2dynamodb.test", "applied_changes.4", `/price: coerced "9.5" to 9.5`),
+					resource.TestCheckResourceAttr("data.json2dynamodb.test", "applied_changes.5", `/shipping/days: set default 3`),
+					resource.TestCheckResourceAttr("data.json2dynamodb.test", "applied_changes.6", `/shipping/method: set default "standard"`),
+					resource.TestCheckResourceAttr("data.json2dynamodb.test", "applied_changes.7", `/tags/0: coerced "1" to 1`),
+					resource.TestCheckNoResourceAttr("data.json2dynamodb.plain", "applied_changes"),
+				),
+			},
+			{
+				Config: testDataSourceConfig_applyDefaults + `
+data "json2dynamodb" "invalid" {
+  json         = jsonencode({ id = "a", count = "4.5", status = "new" })
+  spec         = local.order
+  coerce_types = true
+}
+`,
+				ExpectError: regexp.MustCompile(`at '/count': got string, want integer`),
+			},
+			{
+				Config: `
+data "json2dynamodb" "test" {
+  json           = jsonencode({ id = "a" })
+  apply_defaults = true
+}
+`,
+				ExpectError: regexp.MustCompile(`Applying defaults or coercing types from the schema requires spec`),
+			},
 		},
 	})
 }
@@ -0,0 +1,203 @@
+package provider
+
+import (
+	"context"
+	"maps"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &JSON2DynamoDBUpdateExpressionDataSource{}
+var _ datasource.DataSourceWithConfigure = &JSON2DynamoDBUpdateExpressionDataSource{}
+
+func NewJSON2DynamoDBUpdateExpressionDataSource() datasource.DataSource {
+	return &JSON2DynamoDBUpdateExpressionDataSource{}
+}
+
+// JSON2DynamoDBUpdateExpressionDataSource defines the data source implementation.
+type JSON2DynamoDBUpdateExpressionDataSource struct {
+	specs *specCache
+}
+
+// JSON2DynamoDBUpdateExpressionDataSourceModel describes the data source data model.
+type JSON2DynamoDBUpdateExpressionDataSourceModel struct {
+	EncodeOptionsModel
+
+	JSON                      jsontypes.Normalized `tfsdk:"json"`
+	Remove                    types.List           `tfsdk:"remove"`
+	Add                       types.Map            `tfsdk:"add"`
+	MergeMaps                 types.Bool           `tfsdk:"merge_maps"`
+	UpdateExpression          types.String         `tfsdk:"update_expression"`
+	ExpressionAttributeNames  types.Map            `tfsdk:"expression_attribute_names"`
+	ExpressionAttributeValues jsontypes.Normalized `tfsdk:"expression_attribute_values"`
+	Id                        types.String         `tfsdk:"id"`
+}
+
+func (d *JSON2DynamoDBUpdateExpressionDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_update_expression"
+}
+
+func (d *JSON2DynamoDBUpdateExpressionDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := encodeOptionsAttributes()
+	maps.Copy(attributes, map[string]schema.Attribute{
+		"json": schema.StringAttribute{
+			MarkdownDescription: "Partial JSON object whose attributes are set on the item. Defaults to `{}`",
+			Optional:            true,
+			CustomType:          jsontypes.NormalizedType{},
+		},
+		"remove": schema.ListAttribute{
+			MarkdownDescription: "Paths of the attributes to remove, e.g. `/address/line2`. Attribute paths are written like JSON Pointers, with `~1` and `~0` escaping `/` and `~` in names, but are not JSON Pointers: list indexes are written in brackets, e.g. `/history/[0]`, and every other segment, such as the `2024` of `/scores/2024`, is an attribute name.",
+			Optional:            true,
+			ElementType:         types.StringType,
+		},
+		"add": schema.MapAttribute{
+			MarkdownDescription: "Map of attribute paths, written as in `remove`, to the numbers to add to them, e.g. `{ \"/views\" = 1 }` or `{ \"/counters/[0]\" = 1 }`. A missing attribute is set to the number.",
+			Optional:            true,
+			ElementType:         types.StringType,
+		},
+		"merge_maps": schema.BoolAttribute{
+			MarkdownDescription: "Set the attributes of nested objects one by one (`SET #n0.#n1 = :v0`), keeping the other attributes of the existing map, instead of replacing the whole map. The map must already exist on the item.",
+			Optional:            true,
+		},
+		"update_expression": schema.StringAttribute{
+			MarkdownDescription: "UpdateItem `UpdateExpression`",
+			Computed:            true,
+		},
+		"expression_attribute_names": schema.MapAttribute{
+			MarkdownDescription: "UpdateItem `ExpressionAttributeNames`. Every attribute name is aliased, so reserved words are always safe.",
+			Computed:            true,
+			ElementType:         types.StringType,
+		},
+		"expression_attribute_values": schema.StringAttribute{
+			MarkdownDescription: "UpdateItem `ExpressionAttributeValues` in DynamoDB JSON. Null when the expression only removes attributes.",
+			Computed:            true,
+			CustomType:          jsontypes.NormalizedType{},
+		},
+		"id": schema.StringAttribute{
+			MarkdownDescription: "The ID of this data source",
+			Computed:            true,
+		},
+	})
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Partial JSON into a DynamoDB UpdateItem update expression, for patching attributes of an existing item",
+
+		Attributes: attributes,
+	}
+}
+
+func (d *JSON2DynamoDBUpdateExpressionDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	pd, diags := configuredProviderData(req.ProviderData)
+	resp.Diagnostics.Append(diags...)
+
+	if pd != nil {
+		d.specs = pd.specs
+	}
+}
+
+func (d *JSON2DynamoDBUpdateExpressionDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JSON2DynamoDBUpdateExpressionDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	opts, diags := data.encodeOptions(ctx, d.specs)
+	resp.Diagnostics.Append(diags...)
+
+	var remove []string
+	resp.Diagnostics.Append(data.Remove.ElementsAs(ctx, &remove, false)...)
+
+	var add map[string]string
+	resp.Diagnostics.Append(data.Add.ElementsAs(ctx, &add, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	encoder, diags := newItemEncoder(opts)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.setSpecDraft(encoder)
+
+	input := data.JSON.ValueString()
+	if input == "" {
+		input = "{}"
+	}
+	avs, diags := encoder.encodeJSON(input)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(data.setAppliedChanges(ctx, encoder)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	update := newUpdateExpression()
+	for _, k := range slices.Sorted(maps.Keys(avs)) {
+		if err := update.Set([]pathSegment{{name: k}}, avs[k], data.MergeMaps.ValueBool()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("json"), "Invalid Update", err.Error())
+		}
+	}
+	for i, p := range remove {
+		segments, err := parseAttributePath(p)
+		if err == nil {
+			err = update.Remove(segments)
+		}
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("remove").AtListIndex(i), "Invalid Update", err.Error())
+		}
+	}
+	for _, p := range slices.Sorted(maps.Keys(add)) {
+		segments, err := parseAttributePath(p)
+		if err == nil {
+			err = update.Add(segments, add[p])
+		}
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("add").AtMapKey(p), "Invalid Update", err.Error())
+		}
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	expression := update.String()
+	if expression == "" {
+		resp.Diagnostics.AddError(
+			"Empty Update",
+			"At least one attribute must be set in json, or listed in remove or add.",
+		)
+		return
+	}
+
+	data.ExpressionAttributeValues = jsontypes.NewNormalizedNull()
+	if len(update.values) > 0 {
+		values, diags := serializeItem(update.values)
+		resp.Diagnostics.Append(diags...)
+		data.ExpressionAttributeValues = jsontypes.NewNormalizedValue(values)
+	}
+
+	data.ExpressionAttributeNames, diags = types.MapValueFrom(ctx, types.StringType, update.names)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.UpdateExpression = types.StringValue(expression)
+	data.Id = types.StringValue("-")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
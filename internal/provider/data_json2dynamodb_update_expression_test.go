@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+const testUpdateExpressionDataSourceConfig_basic = `
+data "json2dynamodb_update_expression" "test" {
+  json = jsonencode({
+    name    = "one"
+    status  = "active"
+    tags    = ["a", "b"]
+    address = { city = "Springfield", zip = "12345" }
+  })
+
+  type_hints = { "/tags" = "SS" }
+  remove     = ["/legacy", "/history/[0]"]
+  add        = { "/views" = 1 }
+  merge_maps = true
+}
+
+data "json2dynamodb_update_expression" "remove_only" {
+  remove = ["/legacy"]
+}
+
+data "json2dynamodb_update_expression" "numeric_keys" {
+  json       = jsonencode({ scores = { "2024" = 1 } })
+  remove     = ["/scores/2023"]
+  add        = { "/history/[1]/count" = 2 }
+  merge_maps = true
+}
+`
+
+const testUpdateExpressionDataSourceConfig_overlap = `
+data "json2dynamodb_update_expression" "test" {
+  json   = jsonencode({ address = { city = "Springfield" } })
+  remove = ["/address/zip"]
+}
+`
+
+func TestUpdateExpressionDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		Steps: []resource.TestStep{
+			{
+				Config: testUpdateExpressionDataSourceConfig_basic,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.json2dynamodb_update_expression.test", "update_expression",
+						"SET #n0.#n1 = :v0, #n0.#n2 = :v1, #n3 = :v2, #n4 = :v3, #n5 = :v4 REMOVE #n6, #n7[0] ADD #n8 :v5",
+					),
+					resource.TestCheckResourceAttr("data.json2dynamodb_update_expression.test", "expression_attribute_names.%", "9"),
+					resource.TestCheckResourceAttr("data.json2dynamodb_update_expression.test", "expression_attribute_names.#n0", "address"),
+					resource.TestCheckResourceAttr("data.json2dynamodb_update_expression.test", "expression_attribute_names.#n4", "status"),
+					resource.TestCheckResourceAttr("data.json2dynamodb_update_expression.test", "expression_attribute_names.#n7", "history"),
+					resource.TestCheckResourceAttr("data.json2dynamodb_update_expression.test", "expression_attribute_values",
+						`{":v0":{"S":"Springfield"},":v1":{"S":"12345"},":v2":{"S":"one"},":v3":{"S":"active"},":v4":{"SS":["a","b"]},":v5":{"N":"1"}}`,
+					),
+					resource.TestCheckResourceAttr("data.json2dynamodb_update_expression.remove_only", "update_expression", "REMOVE #n0"),
+					resource.TestCheckNoResourceAttr("data.json2dynamodb_update_expression.remove_only", "expression_attribute_values"),
+					// Numeric map keys are names; only bracketed segments are
+					// list indexes.
+					resource.TestCheckResourceAttr("data.json2dynamodb_update_expression.numeric_keys", "update_expression",
+						"SET #n0.#n1 = :v0 REMOVE #n0.#n2 ADD #n3[1].#n4 :v1",
+					),
+					resource.TestCheckResourceAttr("data.json2dynamodb_update_expression.numeric_keys", "expression_attribute_names.#n1", "2024"),
+					resource.TestCheckResourceAttr("data.json2dynamodb_update_expression.numeric_keys", "expression_attribute_names.#n2", "2023"),
+				),
+			},
+			{
+				Config:      testUpdateExpressionDataSourceConfig_overlap,
+				ExpectError: regexp.MustCompile(`path /address/zip overlaps path /address`),
+			},
+			{
+				Config: `
+data "json2dynamodb_update_expression" "test" {
+  remove = ["/[0]"]
+}
+`,
+				ExpectError: regexp.MustCompile(`the top-level attribute of /\[0\] cannot be a list index`),
+			},
+			{
+				Config: `
+data "json2dynamodb_update_expression" "test" {
+  remove = ["address"]
+}
+`,
+				ExpectError: regexp.MustCompile(`attribute path "address" must start with "/"`),
+			},
+		},
+	})
+}
@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// awsConfig is the AWS configuration of the provider. Empty fields are
+// resolved by the standard AWS configuration chain: environment variables,
+// shared config and credentials files, and instance roles.
+type awsConfig struct {
+	Region   string
+	Endpoint string
+	Profile  string
+	// MaxRetries is the number of times a failed request is retried, or -1
+	// for the SDK default.
+	MaxRetries int
+	AssumeRole *assumeRoleConfig
+	// Unknown names the provider attributes whose values were not known
+	// yet when the provider was configured. No client is created until they
+	// are, rather than falling back to the configuration chain.
+	Unknown []string
+}
+
+// assumeRoleConfig describes a role to assume with the credentials of the
+// configuration chain.
+type assumeRoleConfig struct {
+	RoleARN     string
+	SessionName string
+	ExternalID  string
+	Duration    time.Duration
+}
+
+// dynamoDBClient is the DynamoDB client shared by the resources and data
+// sources of a provider. The client is created on first use, so the
+// conversion data sources and functions, which never call AWS, work without
+// AWS configuration.
+type dynamoDBClient struct {
+	cfg awsConfig
+
+	once   sync.Once
+	client *dynamodb.Client
+	err    error
+}
+
+func newDynamoDBClient(cfg awsConfig) *dynamoDBClient {
+	return &dynamoDBClient{cfg: cfg}
+}
+
+// get returns the shared client. Requests go to endpoint instead of the
+// endpoint of the provider when it is set, e.g. to DynamoDB Local.
+func (c *dynamoDBClient) get(ctx context.Context, endpoint string) (*dynamodb.Client, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	// Prevent panic if the provider has not been configured.
+	if c == nil {
+		diags.AddError(
+			"Unconfigured DynamoDB Client",
+			"Expected a configured DynamoDB client. Please report this issue to the provider developers.",
+		)
+		return nil, diags
+	}
+
+	if len(c.cfg.Unknown) > 0 {
+		diags.AddError(
+			"Unknown AWS Configuration",
+			fmt.Sprintf("The provider cannot create a DynamoDB client, as the value of %s is not known yet. Set it to a value known when planning, or apply the resources it depends on first, e.g. with -target.", strings.Join(c.cfg.Unknown, ", ")),
+		)
+		return nil, diags
+	}
+
+	c.once.Do(func() {
+		c.client, c.err = c.cfg.newClient(ctx)
+	})
+	if c.err != nil {
+		diags.AddError(
+			"AWS Configuration Failed",
+			fmt.Sprintf("The provider received an unexpected error while attempting to load the AWS configuration.\n\nError: %s", c.err),
+		)
+		return nil, diags
+	}
+
+	if endpoint == "" {
+		return c.client, diags
+	}
+	return dynamodb.New(c.client.Options(), func(o *dynamodb.Options) {
+		o.BaseEndpoint = aws.String(endpoint)
+	}), diags
+}
+
+// newClient loads the AWS configuration and returns a DynamoDB client for it.
+func (cfg awsConfig) newClient(ctx context.Context) (*dynamodb.Client, error) {
+	var opts []func(*config.LoadOptions) error
+	if cfg.Region != "" {
+		opts = append(opts, config.WithRegion(cfg.Region))
+	}
+	if cfg.Profile != "" {
+		opts = append(opts, config.WithSharedConfigProfile(cfg.Profile))
+	}
+	if cfg.MaxRetries >= 0 {
+		// The SDK counts the first attempt as well.
+		opts = append(opts, config.WithRetryMaxAttempts(cfg.MaxRetries+1))
+	}
+
+	awsCfg, err := config.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if role := cfg.AssumeRole; role != nil {
+		provider := stscreds.NewAssumeRoleProvider(sts.NewFromConfig(awsCfg), role.RoleARN, func(o *stscreds.AssumeRoleOptions) {
+			if role.SessionName != "" {
+				o.RoleSessionName = role.SessionName
+			}
+			if role.ExternalID != "" {
+				o.ExternalID = aws.String(role.ExternalID)
+			}
+			if role.Duration != 0 {
+				o.Duration = role.Duration
+			}
+		})
+		awsCfg.Credentials = aws.NewCredentialsCache(provider)
+	}
+
+	return dynamodb.NewFromConfig(awsCfg, func(o *dynamodb.Options) {
+		if cfg.Endpoint != "" {
+			o.BaseEndpoint = aws.String(cfg.Endpoint)
+		}
+	}), nil
+}
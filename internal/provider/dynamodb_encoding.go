@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 
@@ -32,6 +33,21 @@ func SerializeAttributeMap(v map[string]types.AttributeValue) (jsonBytes []byte,
 	return json.Marshal(j)
 }
 
+func SerializeAttributeList(v []types.AttributeValue) (jsonBytes []byte, err error) {
+	value := smithyjson.NewEncoder()
+	if err = serializeDocumentListAttributeValue(v, value.Value); err != nil {
+		return
+	}
+
+	var j interface{}
+	err = json.Unmarshal(value.Bytes(), &j)
+	if err != nil {
+		return
+	}
+
+	return json.Marshal(j)
+}
+
 func serializeDocumentAttributeValue(v types.AttributeValue, value smithyjson.Value) error {
 	object := value.Object()
 	defer object.Close()
@@ -161,3 +177,325 @@ func serializeDocumentMapAttributeValue(v map[string]types.AttributeValue, value
 	}
 	return nil
 }
+
+func DeserializeAttributeMap(jsonBytes []byte) (v map[string]types.AttributeValue, err error) {
+	var j interface{}
+	if err = json.Unmarshal(jsonBytes, &j); err != nil {
+		return
+	}
+	if _, ok := j.(map[string]interface{}); !ok {
+		return nil, fmt.Errorf("expected a JSON object of attribute values, got %T instead", j)
+	}
+	err = deserializeDocumentMapAttributeValue(&v, j)
+	return
+}
+
+func deserializeDocumentAttributeValue(v *types.AttributeValue, value interface{}) error {
+	if v == nil {
+		return fmt.Errorf("unexpected nil of type %T", v)
+	}
+	if value == nil {
+		return nil
+	}
+
+	shape, ok := value.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("unexpected JSON type %v", value)
+	}
+
+	var uv types.AttributeValue
+loop:
+	for key, value := range shape {
+		if value == nil {
+			continue
+		}
+		switch key {
+		case "B":
+			var mv []byte
+			if value != nil {
+				jtv, ok := value.(string)
+				if !ok {
+					return fmt.Errorf("expected BinaryAttributeValue to be []byte, got %T instead", value)
+				}
+				dv, err := base64.StdEncoding.DecodeString(jtv)
+				if err != nil {
+					return fmt.Errorf("failed to base64 decode BinaryAttributeValue, %w", err)
+				}
+				mv = dv
+			}
+			uv = &types.AttributeValueMemberB{Value: mv}
+			break loop
+
+		case "BOOL":
+			var mv bool
+			if value != nil {
+				jtv, ok := value.(bool)
+				if !ok {
+					return fmt.Errorf("expected BooleanAttributeValue to be of type *bool, got %T instead", value)
+				}
+				mv = jtv
+			}
+			uv = &types.AttributeValueMemberBOOL{Value: mv}
+			break loop
+
+		case "BS":
+			var mv [][]byte
+			if err := deserializeDocumentBinarySetAttributeValue(&mv, value); err != nil {
+				return err
+			}
+			uv = &types.AttributeValueMemberBS{Value: mv}
+			break loop
+
+		case "L":
+			var mv []types.AttributeValue
+			if err := deserializeDocumentListAttributeValue(&mv, value); err != nil {
+				return err
+			}
+			uv = &types.AttributeValueMemberL{Value: mv}
+			break loop
+
+		case "M":
+			var mv map[string]types.AttributeValue
+			if err := deserializeDocumentMapAttributeValue(&mv, value); err != nil {
+				return err
+			}
+			uv = &types.AttributeValueMemberM{Value: mv}
+			break loop
+
+		case "N":
+			var mv string
+			if value != nil {
+				jtv, ok := value.(string)
+				if !ok {
+					return fmt.Errorf("expected NumberAttributeValue to be of type string, got %T instead", value)
+				}
+				mv = jtv
+			}
+			uv = &types.AttributeValueMemberN{Value: mv}
+			break loop
+
+		case "NS":
+			var mv []string
+			if err := deserializeDocumentNumberSetAttributeValue(&mv, value); err != nil {
+				return err
+			}
+			uv = &types.AttributeValueMemberNS{Value: mv}
+			break loop
+
+		case "NULL":
+			var mv bool
+			if value != nil {
+				jtv, ok := value.(bool)
+				if !ok {
+					return fmt.Errorf("expected NullAttributeValue to be of type *bool, got %T instead", value)
+				}
+				mv = jtv
+			}
+			uv = &types.AttributeValueMemberNULL{Value: mv}
+			break loop
+
+		case "S":
+			var mv string
+			if value != nil {
+				jtv, ok := value.(string)
+				if !ok {
+					return fmt.Errorf("expected StringAttributeValue to be of type string, got %T instead", value)
+				}
+				mv = jtv
+			}
+			uv = &types.AttributeValueMemberS{Value: mv}
+			break loop
+
+		case "SS":
+			var mv []string
+			if err := deserializeDocumentStringSetAttributeValue(&mv, value); err != nil {
+				return err
+			}
+			uv = &types.AttributeValueMemberSS{Value: mv}
+			break loop
+
+		default:
+			uv = &types.UnknownUnionMember{Tag: key}
+			break loop
+
+		}
+	}
+	*v = uv
+	return nil
+}
+
+func deserializeDocumentBinarySetAttributeValue(v *[][]byte, value interface{}) error {
+	if v == nil {
+		return fmt.Errorf("unexpected nil of type %T", v)
+	}
+	if value == nil {
+		return nil
+	}
+
+	shape, ok := value.([]interface{})
+	if !ok {
+		return fmt.Errorf("unexpected JSON type %v", value)
+	}
+
+	var cv [][]byte
+	if *v == nil {
+		cv = [][]byte{}
+	} else {
+		cv = *v
+	}
+
+	for _, value := range shape {
+		var col []byte
+		if value != nil {
+			jtv, ok := value.(string)
+			if !ok {
+				return fmt.Errorf("expected BinaryAttributeValue to be []byte, got %T instead", value)
+			}
+			dv, err := base64.StdEncoding.DecodeString(jtv)
+			if err != nil {
+				return fmt.Errorf("failed to base64 decode BinaryAttributeValue, %w", err)
+			}
+			col = dv
+		}
+		cv = append(cv, col)
+
+	}
+	*v = cv
+	return nil
+}
+
+func deserializeDocumentListAttributeValue(v *[]types.AttributeValue, value interface{}) error {
+	if v == nil {
+		return fmt.Errorf("unexpected nil of type %T", v)
+	}
+	if value == nil {
+		return nil
+	}
+
+	shape, ok := value.([]interface{})
+	if !ok {
+		return fmt.Errorf("unexpected JSON type %v", value)
+	}
+
+	var cv []types.AttributeValue
+	if *v == nil {
+		cv = []types.AttributeValue{}
+	} else {
+		cv = *v
+	}
+
+	for _, value := range shape {
+		var col types.AttributeValue
+		if err := deserializeDocumentAttributeValue(&col, value); err != nil {
+			return err
+		}
+		cv = append(cv, col)
+
+	}
+	*v = cv
+	return nil
+}
+
+func deserializeDocumentMapAttributeValue(v *map[string]types.AttributeValue, value interface{}) error {
+	if v == nil {
+		return fmt.Errorf("unexpected nil of type %T", v)
+	}
+	if value == nil {
+		return nil
+	}
+
+	shape, ok := value.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("unexpected JSON type %v", value)
+	}
+
+	var mv map[string]types.AttributeValue
+	if *v == nil {
+		mv = map[string]types.AttributeValue{}
+	} else {
+		mv = *v
+	}
+
+	for key, value := range shape {
+		var parsedVal types.AttributeValue
+		if err := deserializeDocumentAttributeValue(&parsedVal, value); err != nil {
+			return err
+		}
+		mv[key] = parsedVal
+
+	}
+	*v = mv
+	return nil
+}
+
+func deserializeDocumentNumberSetAttributeValue(v *[]string, value interface{}) error {
+	if v == nil {
+		return fmt.Errorf("unexpected nil of type %T", v)
+	}
+	if value == nil {
+		return nil
+	}
+
+	shape, ok := value.([]interface{})
+	if !ok {
+		return fmt.Errorf("unexpected JSON type %v", value)
+	}
+
+	var cv []string
+	if *v == nil {
+		cv = []string{}
+	} else {
+		cv = *v
+	}
+
+	for _, value := range shape {
+		var col string
+		if value != nil {
+			jtv, ok := value.(string)
+			if !ok {
+				return fmt.Errorf("expected NumberAttributeValue to be of type string, got %T instead", value)
+			}
+			col = jtv
+		}
+		cv = append(cv, col)
+
+	}
+	*v = cv
+	return nil
+}
+
+func deserializeDocumentStringSetAttributeValue(v *[]string, value interface{}) error {
+	if v == nil {
+		return fmt.Errorf("unexpected nil of type %T", v)
+	}
+	if value == nil {
+		return nil
+	}
+
+	shape, ok := value.([]interface{})
+	if !ok {
+		return fmt.Errorf("unexpected JSON type %v", value)
+	}
+
+	var cv []string
+	if *v == nil {
+		cv = []string{}
+	} else {
+		cv = *v
+	}
+
+	for _, value := range shape {
+		var col string
+		if value != nil {
+			jtv, ok := value.(string)
+			if !ok {
+				return fmt.Errorf("expected StringAttributeValue to be of type string, got %T instead", value)
+			}
+			col = jtv
+		}
+		cv = append(cv, col)
+
+	}
+	*v = cv
+	return nil
+}
@@ -0,0 +1,500 @@
+package provider
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeDynamoDB is an in-memory stand-in for the DynamoDB API, so resources
+// and data sources that call DynamoDB can be tested without AWS or DynamoDB
+// Local. It implements the operations the provider uses, on tables created
+// with createTable.
+type fakeDynamoDB struct {
+	*httptest.Server
+
+	mu     sync.Mutex
+	tables map[string]*fakeTable
+	// credential is the access key and region the last DynamoDB request was
+	// signed with, e.g. "test/us-east-1".
+	credential string
+	// assumedRoles are the roles assumed with STS AssumeRole, which the fake
+	// also serves.
+	assumedRoles []url.Values
+	// pageSize is the number of items Query and Scan read per page, as if
+	// pages were limited by size. Zero reads all the items.
+	pageSize int
+	// unprocessed is the number of BatchWriteItem and BatchGetItem calls
+	// that leave their last request unprocessed, as if throttled.
+	unprocessed int
+}
+
+// fakeTable holds the items of a table by their key, as raw DynamoDB JSON
+// attribute values.
+type fakeTable struct {
+	hashKey  string
+	rangeKey string
+	items    map[string]map[string]json.RawMessage
+}
+
+// fakeRequest is the union of the request fields the fake reads.
+type fakeRequest struct {
+	TableName                 string
+	Item                      map[string]json.RawMessage
+	Key                       map[string]json.RawMessage
+	ConditionExpression       string
+	ProjectionExpression      string
+	KeyConditionExpression    string
+	FilterExpression          string
+	ExpressionAttributeNames  map[string]string
+	ExpressionAttributeValues map[string]json.RawMessage
+	ExclusiveStartKey         map[string]json.RawMessage
+	Limit                     int
+	ScanIndexForward          *bool
+	Segment                   int
+	TotalSegments             int
+	RequestItems              json.RawMessage
+}
+
+// newFakeDynamoDB starts a fake DynamoDB endpoint and points the AWS
+// configuration of the test at it with static credentials.
+func newFakeDynamoDB(t *testing.T) *fakeDynamoDB {
+	f := &fakeDynamoDB{tables: map[string]*fakeTable{}}
+	f.Server = httptest.NewServer(http.HandlerFunc(f.serveHTTP))
+	t.Cleanup(f.Close)
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "test")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test")
+	t.Setenv("AWS_SESSION_TOKEN", "")
+	t.Setenv("AWS_PROFILE", "")
+	t.Setenv("AWS_REGION", "us-east-1")
+	t.Setenv("AWS_CONFIG_FILE", t.TempDir()+"/config")
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", t.TempDir()+"/credentials")
+	t.Setenv("AWS_EC2_METADATA_DISABLED", "true")
+	t.Setenv("AWS_ENDPOINT_URL_DYNAMODB", f.URL)
+	t.Setenv("AWS_ENDPOINT_URL_STS", f.URL)
+	return f
+}
+
+// createTable creates an empty table. rangeKey is empty for tables with a
+// hash key only.
+func (f *fakeDynamoDB) createTable(name, hashKey, rangeKey string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.tables[name] = &fakeTable{hashKey: hashKey, rangeKey: rangeKey, items: map[string]map[string]json.RawMessage{}}
+}
+
+// putItem stores an item given in DynamoDB JSON, replacing the item with the
+// same key.
+func (f *fakeDynamoDB) putItem(t *testing.T, tableName, item string) {
+	t.Helper()
+	var avs map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(item), &avs); err != nil {
+		t.Fatal(err)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	table := f.tables[tableName]
+	table.items[table.key(avs)] = avs
+}
+
+// items returns the items of a table in DynamoDB JSON, by their key.
+func (f *fakeDynamoDB) items(tableName string) map[string]string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	items := map[string]string{}
+	for k, item := range f.tables[tableName].items {
+		b, _ := json.Marshal(item)
+		items[k] = string(b)
+	}
+	return items
+}
+
+// checkItems checks that a table holds exactly the given items, by the
+// DynamoDB JSON of their key.
+func (f *fakeDynamoDB) checkItems(tableName string, want map[string]string) func() error {
+	return func() error {
+		got := f.items(tableName)
+		if len(got) != len(want) {
+			return fmt.Errorf("expected %d items in table %q, got %d: %v", len(want), tableName, len(got), got)
+		}
+		for k, item := range want {
+			if got[k] != item {
+				return fmt.Errorf("expected item %s in table %q to be %s, got %q", k, tableName, item, got[k])
+			}
+		}
+		return nil
+	}
+}
+
+// key returns the key of an item in DynamoDB JSON. Numbers are compared by
+// value, as in DynamoDB, so {"N":"1.0"} and {"N":"1"} are the same key.
+func (t *fakeTable) key(item map[string]json.RawMessage) string {
+	key := map[string]json.RawMessage{t.hashKey: fakeKeyValue(item[t.hashKey])}
+	if t.rangeKey != "" {
+		key[t.rangeKey] = fakeKeyValue(item[t.rangeKey])
+	}
+	b, _ := json.Marshal(key)
+	return string(b)
+}
+
+func fakeKeyValue(v json.RawMessage) json.RawMessage {
+	var n struct{ N string }
+	if err := json.Unmarshal(v, &n); err != nil || n.N == "" {
+		return v
+	}
+	d, err := canonicalDecimal(n.N)
+	if err != nil {
+		return v
+	}
+	b, _ := json.Marshal(map[string]string{"N": d})
+	return b
+}
+
+// lastCredential returns the access key and region the last DynamoDB request
+// was signed with.
+func (f *fakeDynamoDB) lastCredential() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.credential
+}
+
+func (f *fakeDynamoDB) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("X-Amz-Target") == "" {
+		f.serveSTS(w, r)
+		return
+	}
+
+	var req fakeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		fakeError(w, "SerializationException", err.Error())
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// Authorization: AWS4-HMAC-SHA256 Credential=<key>/<date>/<region>/dynamodb/aws4_request, ...
+	_, credential, _ := strings.Cut(r.Header.Get("Authorization"), "Credential=")
+	if scope := strings.Split(credential, "/"); len(scope) > 2 {
+		f.credential = scope[0] + "/" + scope[2]
+	}
+
+	_, operation, _ := strings.Cut(r.Header.Get("X-Amz-Target"), ".")
+	switch operation {
+	case "BatchWriteItem":
+		f.batchWriteItem(w, req.RequestItems)
+		return
+	case "BatchGetItem":
+		f.batchGetItem(w, req.RequestItems)
+		return
+	}
+
+	table, ok := f.tables[req.TableName]
+	if !ok {
+		fakeError(w, "ResourceNotFoundException", "Requested resource not found")
+		return
+	}
+
+	switch operation {
+	case "DescribeTable":
+		keySchema := []map[string]string{{"AttributeName": table.hashKey, "KeyType": "HASH"}}
+		if table.rangeKey != "" {
+			keySchema = append(keySchema, map[string]string{"AttributeName": table.rangeKey, "KeyType": "RANGE"})
+		}
+		fakeResponse(w, map[string]interface{}{
+			"Table": map[string]interface{}{"TableName": req.TableName, "KeySchema": keySchema},
+		})
+	case "PutItem":
+		key := table.key(req.Item)
+		// The only condition the provider writes is that the item does not
+		// exist yet.
+		if _, exists := table.items[key]; exists && req.ConditionExpression != "" {
+			fakeError(w, "ConditionalCheckFailedException", "The conditional request failed")
+			return
+		}
+		table.items[key] = req.Item
+		fakeResponse(w, map[string]interface{}{})
+	case "GetItem":
+		resp := map[string]interface{}{}
+		if item, ok := table.items[table.key(req.Key)]; ok {
+			resp["Item"] = req.project(item)
+		}
+		fakeResponse(w, resp)
+	case "DeleteItem":
+		delete(table.items, table.key(req.Key))
+		fakeResponse(w, map[string]interface{}{})
+	case "Query", "Scan":
+		items, lastKey := table.read(&req, operation == "Query", f.pageSize)
+		resp := map[string]interface{}{"Items": items, "Count": len(items)}
+		if lastKey != nil {
+			resp["LastEvaluatedKey"] = lastKey
+		}
+		fakeResponse(w, resp)
+	default:
+		fakeError(w, "UnknownOperationException", fmt.Sprintf("operation %q is not implemented", operation))
+	}
+}
+
+// batchWriteItem applies the put and delete requests of a BatchWriteItem
+// call, except the last one while f.unprocessed is positive.
+func (f *fakeDynamoDB) batchWriteItem(w http.ResponseWriter, requestItems json.RawMessage) {
+	var requests map[string][]struct {
+		PutRequest    *struct{ Item map[string]json.RawMessage }
+		DeleteRequest *struct{ Key map[string]json.RawMessage }
+	}
+	if err := json.Unmarshal(requestItems, &requests); err != nil {
+		fakeError(w, "SerializationException", err.Error())
+		return
+	}
+	for name := range requests {
+		if _, ok := f.tables[name]; !ok {
+			fakeError(w, "ResourceNotFoundException", "Requested resource not found")
+			return
+		}
+	}
+
+	for name, writes := range requests {
+		table := f.tables[name]
+		keys := map[string]bool{}
+		for _, write := range writes {
+			var key map[string]json.RawMessage
+			if write.PutRequest != nil {
+				key = write.PutRequest.Item
+			} else {
+				key = write.DeleteRequest.Key
+			}
+			if keys[table.key(key)] {
+				fakeError(w, "ValidationException", "Provided list of item keys contains duplicates")
+				return
+			}
+			keys[table.key(key)] = true
+		}
+	}
+
+	unprocessed := map[string]interface{}{}
+	for name, writes := range requests {
+		table := f.tables[name]
+		if f.unprocessed > 0 {
+			f.unprocessed--
+			unprocessed[name] = writes[len(writes)-1:]
+			writes = writes[:len(writes)-1]
+		}
+		for _, write := range writes {
+			if write.PutRequest != nil {
+				table.items[table.key(write.PutRequest.Item)] = write.PutRequest.Item
+			} else {
+				delete(table.items, table.key(write.DeleteRequest.Key))
+			}
+		}
+	}
+	fakeResponse(w, map[string]interface{}{"UnprocessedItems": unprocessed})
+}
+
+// batchGetItem reads the items of a BatchGetItem call, except the last one
+// while f.unprocessed is positive.
+func (f *fakeDynamoDB) batchGetItem(w http.ResponseWriter, requestItems json.RawMessage) {
+	var requests map[string]struct {
+		Keys           []map[string]json.RawMessage
+		ConsistentRead bool
+	}
+	if err := json.Unmarshal(requestItems, &requests); err != nil {
+		fakeError(w, "SerializationException", err.Error())
+		return
+	}
+	for name := range requests {
+		if _, ok := f.tables[name]; !ok {
+			fakeError(w, "ResourceNotFoundException", "Requested resource not found")
+			return
+		}
+	}
+
+	responses := map[string][]map[string]json.RawMessage{}
+	unprocessed := map[string]interface{}{}
+	for name, request := range requests {
+		table := f.tables[name]
+		keys := request.Keys
+		if f.unprocessed > 0 {
+			f.unprocessed--
+			unprocessed[name] = map[string]interface{}{"Keys": keys[len(keys)-1:], "ConsistentRead": request.ConsistentRead}
+			keys = keys[:len(keys)-1]
+		}
+		responses[name] = []map[string]json.RawMessage{}
+		for _, key := range keys {
+			if item, ok := table.items[table.key(key)]; ok {
+				responses[name] = append(responses[name], item)
+			}
+		}
+	}
+	fakeResponse(w, map[string]interface{}{"Responses": responses, "UnprocessedKeys": unprocessed})
+}
+
+// serveSTS serves AssumeRole, with the credentials of the session in the
+// access key "ASSUMED".
+func (f *fakeDynamoDB) serveSTS(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil || r.Form.Get("Action") != "AssumeRole" {
+		http.Error(w, "unexpected STS request", http.StatusBadRequest)
+		return
+	}
+
+	f.mu.Lock()
+	f.assumedRoles = append(f.assumedRoles, r.Form)
+	f.mu.Unlock()
+
+	w.Header().Set("Content-Type", "text/xml")
+	_, _ = fmt.Fprintf(w, `<AssumeRoleResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/">
+  <AssumeRoleResult>
+    <Credentials>
+      <AccessKeyId>ASSUMED</AccessKeyId>
+      <SecretAccessKey>secret</SecretAccessKey>
+      <SessionToken>token</SessionToken>
+      <Expiration>%s</Expiration>
+    </Credentials>
+    <AssumedRoleUser>
+      <Arn>%s/%s</Arn>
+      <AssumedRoleId>AROA:%s</AssumedRoleId>
+    </AssumedRoleUser>
+  </AssumeRoleResult>
+  <ResponseMetadata><RequestId>1</RequestId></ResponseMetadata>
+</AssumeRoleResponse>`, time.Now().Add(time.Hour).UTC().Format(time.RFC3339), r.Form.Get("RoleArn"), r.Form.Get("RoleSessionName"), r.Form.Get("RoleSessionName"))
+}
+
+// read returns a page of the items a Query or Scan request selects, in the
+// order of their hash and range keys, and the key of the last item read when there may be more.
+func (t *fakeTable) read(req *fakeRequest, query bool, pageSize int) ([]map[string]json.RawMessage, map[string]json.RawMessage) {
+	keys := slices.SortedFunc(maps.Keys(t.items), func(a, b string) int {
+		return cmp.Or(
+			bytes.Compare(t.items[a][t.hashKey], t.items[b][t.hashKey]),
+			bytes.Compare(t.items[a][t.rangeKey], t.items[b][t.rangeKey]),
+		)
+	})
+	if query && req.ScanIndexForward != nil && !*req.ScanIndexForward {
+		slices.Reverse(keys)
+	}
+	if req.TotalSegments > 0 {
+		var segment []string
+		for i, k := range keys {
+			if i%req.TotalSegments == req.Segment {
+				segment = append(segment, k)
+			}
+		}
+		keys = segment
+	}
+	if req.ExclusiveStartKey != nil {
+		if i := slices.Index(keys, t.key(req.ExclusiveStartKey)); i >= 0 {
+			keys = keys[i+1:]
+		}
+	}
+
+	limit := req.Limit
+	if pageSize > 0 && (limit == 0 || pageSize < limit) {
+		limit = pageSize
+	}
+
+	items := []map[string]json.RawMessage{}
+	read := 0
+	for i, k := range keys {
+		item := t.items[k]
+		if query && !req.matches(req.KeyConditionExpression, item) {
+			continue
+		}
+		read++
+		if req.FilterExpression == "" || req.matches(req.FilterExpression, item) {
+			items = append(items, req.project(item))
+		}
+		if read == limit && i < len(keys)-1 {
+			var lastKey map[string]json.RawMessage
+			_ = json.Unmarshal([]byte(k), &lastKey)
+			return items, lastKey
+		}
+	}
+	return items, nil
+}
+
+// matches evaluates a condition expression of the request on an item. Only
+// "a = :v" and "begins_with(a, :v)" conditions joined by AND are supported.
+func (req *fakeRequest) matches(expression string, item map[string]json.RawMessage) bool {
+	for _, condition := range regexp.MustCompile(`(?i)\s+and\s+`).Split(expression, -1) {
+		var name, value string
+		if m := regexp.MustCompile(`^begins_with\((\S+),\s*(\S+)\)$`).FindStringSubmatch(condition); m != nil {
+			name, value = req.operand(m[1]), m[2]
+			var prefix, s map[string]string
+			_ = json.Unmarshal(req.ExpressionAttributeValues[value], &prefix)
+			_ = json.Unmarshal(item[name], &s)
+			if s["S"] == "" || !strings.HasPrefix(s["S"], prefix["S"]) {
+				return false
+			}
+			continue
+		}
+		name, value, _ = strings.Cut(condition, "=")
+		name, value = req.operand(strings.TrimSpace(name)), strings.TrimSpace(value)
+		if item[name] == nil || !bytes.Equal(compactJSON(item[name]), compactJSON(req.ExpressionAttributeValues[value])) {
+			return false
+		}
+	}
+	return true
+}
+
+// operand resolves an attribute name placeholder of the request.
+func (req *fakeRequest) operand(name string) string {
+	if n, ok := req.ExpressionAttributeNames[name]; ok {
+		return n
+	}
+	return name
+}
+
+func compactJSON(b []byte) []byte {
+	var buf bytes.Buffer
+	_ = json.Compact(&buf, b)
+	return buf.Bytes()
+}
+
+// project returns the attributes of an item the projection expression of
+// the request names. Only top-level attributes are supported.
+func (req *fakeRequest) project(item map[string]json.RawMessage) map[string]json.RawMessage {
+	if req.ProjectionExpression == "" {
+		return item
+	}
+	projected := map[string]json.RawMessage{}
+	for _, name := range strings.Split(req.ProjectionExpression, ",") {
+		name = req.operand(strings.TrimSpace(name))
+		if v, ok := item[name]; ok {
+			projected[name] = v
+		}
+	}
+	return projected
+}
+
+// fakeResponse writes a response body with the CRC32 checksum DynamoDB sends
+// with it.
+func fakeResponse(w http.ResponseWriter, v interface{}) {
+	fakeWrite(w, http.StatusOK, v)
+}
+
+func fakeError(w http.ResponseWriter, code, message string) {
+	fakeWrite(w, http.StatusBadRequest, map[string]string{
+		"__type":  "com.amazonaws.dynamodb.v20120810#" + code,
+		"message": message,
+	})
+}
+
+func fakeWrite(w http.ResponseWriter, status int, v interface{}) {
+	b, _ := json.Marshal(v)
+	w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+	w.Header().Set("X-Amz-Crc32", strconv.FormatUint(uint64(crc32.ChecksumIEEE(b)), 10))
+	w.WriteHeader(status)
+	_, _ = w.Write(b)
+}
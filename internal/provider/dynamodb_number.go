@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// dynamoDBNumberPrecision is the maximum number of significant digits
+	// DynamoDB stores for a number.
+	dynamoDBNumberPrecision = 38
+	// dynamoDBNumberMaxExponent and dynamoDBNumberMinExponent bound the
+	// magnitude of a non-zero number written as d.ddd x 10^e, i.e. the range
+	// 1E-130 to 9.9999999999999999999999999999999999999E+125.
+	dynamoDBNumberMaxExponent = 125
+	dynamoDBNumberMinExponent = -130
+)
+
+// numberError reports a number DynamoDB cannot store.
+type numberError struct {
+	msg string
+}
+
+func (e *numberError) Error() string {
+	return e.msg
+}
+
+// validateDynamoDBNumber checks that s is a JSON number literal DynamoDB can
+// store without rounding or rejecting it.
+func validateDynamoDBNumber(s string) error {
+	if !jsonNumberPattern.MatchString(s) {
+		return &numberError{fmt.Sprintf("invalid number %q", s)}
+	}
+
+	mantissa := strings.TrimPrefix(s, "-")
+	exponent := 0
+	if i := strings.IndexAny(mantissa, "eE"); i >= 0 {
+		e, err := strconv.Atoi(mantissa[i+1:])
+		if err != nil {
+			return &numberError{fmt.Sprintf("number %s has an out of range exponent", s)}
+		}
+		exponent = e
+		mantissa = mantissa[:i]
+	}
+
+	// Fold the fractional digits into the exponent so the mantissa is an integer.
+	if i := strings.IndexByte(mantissa, '.'); i >= 0 {
+		exponent -= len(mantissa) - i - 1
+		mantissa = mantissa[:i] + mantissa[i+1:]
+	}
+
+	digits := strings.TrimLeft(mantissa, "0")
+	if digits == "" {
+		// Zero is always representable.
+		return nil
+	}
+	trimmed := strings.TrimRight(digits, "0")
+	exponent += len(digits) - len(trimmed)
+	digits = trimmed
+
+	if len(digits) > dynamoDBNumberPrecision {
+		return &numberError{fmt.Sprintf("number %s has %d significant digits, DynamoDB supports at most %d", s, len(digits), dynamoDBNumberPrecision)}
+	}
+
+	// Normalise to a single digit before the decimal point.
+	exponent += len(digits) - 1
+	if exponent > dynamoDBNumberMaxExponent || exponent < dynamoDBNumberMinExponent {
+		return &numberError{fmt.Sprintf("number %s is outside the DynamoDB range of 1E%d to 9.9999999999999999999999999999999999999E+%d in magnitude", s, dynamoDBNumberMinExponent, dynamoDBNumberMaxExponent)}
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateDynamoDBNumber(t *testing.T) {
+	cases := map[string]bool{
+		"0":                   true,
+		"-0.000":              true,
+		"123":                 true,
+		"1234567890123456789": true,
+		"3.14159":             true,
+		"1E125":               true,
+		"9.9999999999999999999999999999999999999E+125": true,
+		"1e-130":                       true,
+		"-1E-130":                      true,
+		"1" + strings.Repeat("0", 120): true,
+		strings.Repeat("9", 38):        true,
+		strings.Repeat("9", 39):        false,
+		"0." + strings.Repeat("1", 39): false,
+		"1E126":                        false,
+		"10E125":                       false,
+		"1e-131":                       false,
+		"0.1e-130":                     false,
+		"1e99999999999999999999":       false,
+		"01":                           false,
+		"NaN":                          false,
+		"":                             false,
+	}
+
+	for s, valid := range cases {
+		err := validateDynamoDBNumber(s)
+		if valid && err != nil {
+			t.Errorf("expected %q to be valid, got: %s", s, err)
+		}
+		if !valid && err == nil {
+			t.Errorf("expected %q to be invalid", s)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// EncodeOptionsModel describes the encoding arguments shared by the data
+// sources that convert JSON into DynamoDB JSON.
+type EncodeOptionsModel struct {
+	Spec               types.String `tfsdk:"spec"`
+	SpecFile           types.String `tfsdk:"spec_file"`
+	SpecDir            types.String `tfsdk:"spec_dir"`
+	SchemaRef          types.String `tfsdk:"schema_ref"`
+	OpenAPIDirection   types.String `tfsdk:"openapi_direction"`
+	SchemaDialect      types.String `tfsdk:"schema_dialect"`
+	SpecDraft          types.String `tfsdk:"spec_draft"`
+	ValidationMode     types.String `tfsdk:"validation_mode"`
+	ValidationSeverity types.Map    `tfsdk:"validation_severity"`
+	TypeHints          types.Map    `tfsdk:"type_hints"`
+	SchemaTypes        types.Bool   `tfsdk:"schema_types"`
+	EpochDateTimes     types.Bool   `tfsdk:"epoch_date_times"`
+	ApplyDefaults      types.Bool   `tfsdk:"apply_defaults"`
+	CoerceTypes        types.Bool   `tfsdk:"coerce_types"`
+	AppliedChanges     types.List   `tfsdk:"applied_changes"`
+	LimitViolations    types.String `tfsdk:"limit_violations"`
+}
+
+// encodeOptionsAttributes returns the schema attributes of EncodeOptionsModel.
+func encodeOptionsAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"spec": schema.StringAttribute{
+			MarkdownDescription: "JSON Schema in JSON format to validate the JSON against. Drafts 4, 6, 7, 2019-09 and 2020-12 are supported, picked by `$schema`. With `schema_ref`, a whole OpenAPI 3.x document in JSON or YAML instead.",
+			Optional:            true,
+		},
+		"spec_file": schema.StringAttribute{
+			MarkdownDescription: "Path of a file to read `spec` from, in JSON or YAML. Relative `$ref`s such as `common.json#/definitions/address` are resolved against the file. Conflicts with `spec`.",
+			Optional:            true,
+		},
+		"spec_dir": schema.StringAttribute{
+			MarkdownDescription: "Directory a relative `spec_file` is read from. With `spec`, relative `$ref`s in it are resolved against this directory. Documents are cached for the life of the provider, and are only fetched over HTTP(S) when the provider sets `allow_remote_refs`.",
+			Optional:            true,
+		},
+		"schema_ref": schema.StringAttribute{
+			MarkdownDescription: "Reference to the component schema of the OpenAPI document in `spec` to validate the JSON against, e.g. `#/components/schemas/Order`. References within the document are resolved, and `nullable`, `discriminator`, `readOnly` and `writeOnly` are applied.",
+			Optional:            true,
+		},
+		"openapi_direction": schema.StringAttribute{
+			MarkdownDescription: fmt.Sprintf("Whether the JSON is validated as a `%s` (the default), which may not set `readOnly` properties, or a `%s`, which may not set `writeOnly` properties. Only used with `schema_ref`.", openAPIDirectionRequest, openAPIDirectionResponse),
+			Optional:            true,
+		},
+		"schema_dialect": schema.StringAttribute{
+			MarkdownDescription: fmt.Sprintf("JSON Schema draft of a `spec` without `$schema`: one of `%s`. Defaults to `%s`, the draft OpenAPI schemas are based on.", strings.Join(schemaDialectNames(), "`, `"), defaultSchemaDialect),
+			Optional:            true,
+		},
+		"spec_draft": schema.StringAttribute{
+			MarkdownDescription: "The JSON Schema draft `spec` was validated with, from its `$schema` or `schema_dialect`, or `openapi-` and the document version with `schema_ref`. Null without `spec`.",
+			Computed:            true,
+		},
+		"validation_mode": schema.StringAttribute{
+			MarkdownDescription: fmt.Sprintf("How violations of `spec` are reported: `%s` (the default), `%s`, so items that break a stricter spec can still be converted, or `%s`.", validationModeError, validationModeWarn, validationModeOff),
+			Optional:            true,
+		},
+		"validation_severity": schema.MapAttribute{
+			MarkdownDescription: "Overrides `validation_mode` for some violations. Keys are either schema keywords (e.g. `additionalProperties`) or JSON Pointer patterns (e.g. `/legacy` or `/items/*/note`, where `*` matches any key or index), which cover the values below them too. Values are `error`, `warn` or `off`. The longest matching pattern takes precedence, then the keyword.",
+			Optional:            true,
+			ElementType:         types.StringType,
+		},
+		"type_hints": schema.MapAttribute{
+			MarkdownDescription: "Map of JSON Pointer patterns (e.g. `/tags` or `/items/*/blob`, where `*` matches any key or index) to the DynamoDB type the matching values are encoded as: `SS`, `NS`, `BS`, `B`, `N`, `S` or `NULL`. Set members must be unique, binary values must be base64 and numeric strings must be valid numbers. `NULL` converts `null` and empty strings.",
+			Optional:            true,
+			ElementType:         types.StringType,
+		},
+		"schema_types": schema.BoolAttribute{
+			MarkdownDescription: "Derive DynamoDB types from `spec`: `uniqueItems` string and number arrays become `SS` and `NS`, `format: byte` or `binary` strings become `B` (or `BS` in `uniqueItems` arrays), and the `x-dynamodb-type` vendor extension sets a type explicitly. `type_hints` take precedence. Requires `spec`.",
+			Optional:            true,
+		},
+		"epoch_date_times": schema.BoolAttribute{
+			MarkdownDescription: "Encode `format: date-time` strings of `spec` as `N` epoch seconds. A `date-time` property with `x-dynamodb-type: N` is always encoded this way. Requires `schema_types`.",
+			Optional:            true,
+		},
+		"apply_defaults": schema.BoolAttribute{
+			MarkdownDescription: "Fill in missing properties from their `default` in `spec` before validating, then fill in the properties of those defaults in turn. Requires `spec`.",
+			Optional:            true,
+		},
+		"coerce_types": schema.BoolAttribute{
+			MarkdownDescription: "Convert strings to the type `spec` declares before validating: numeric strings such as `\"42\"` to numbers where an `integer` or `number` is expected, and `\"true\"` or `\"false\"` to booleans where a `boolean` is expected. Values that may also be strings are left alone. Requires `spec`.",
+			Optional:            true,
+		},
+		"applied_changes": schema.ListAttribute{
+			MarkdownDescription: "The changes `apply_defaults` and `coerce_types` made to the JSON, e.g. `/count: coerced \"42\" to 42`, each prefixed by the JSON Pointer of the value changed. Null when neither is enabled.",
+			Computed:            true,
+			ElementType:         types.StringType,
+		},
+		"limit_violations": schema.StringAttribute{
+			MarkdownDescription: fmt.Sprintf("How items DynamoDB would reject are reported: `%s` (the default) or `%s`. Items are checked for the %d KB size limit, nesting deeper than %d levels, and empty sets, empty set members and duplicate set members.", limitViolationsError, limitViolationsWarn, dynamoDBMaxItemSize/1024, dynamoDBMaxNestingDepth),
+			Optional:            true,
+		},
+	}
+}
+
+// encodeOptions converts the model into the options of the conversion core.
+// Spec files and their references are read through specs.
+func (m EncodeOptionsModel) encodeOptions(ctx context.Context, specs *specCache) (encodeOptions, diag.Diagnostics) {
+	var typeHints map[string]string
+	diags := m.TypeHints.ElementsAs(ctx, &typeHints, false)
+
+	var severity map[string]string
+	diags.Append(m.ValidationSeverity.ElementsAs(ctx, &severity, false)...)
+
+	return encodeOptions{
+		Spec:               m.Spec.ValueString(),
+		SchemaDialect:      m.SchemaDialect.ValueString(),
+		TypeHints:          typeHints,
+		SchemaTypes:        m.SchemaTypes.ValueBool(),
+		EpochDateTimes:     m.EpochDateTimes.ValueBool(),
+		ApplyDefaults:      m.ApplyDefaults.ValueBool(),
+		CoerceTypes:        m.CoerceTypes.ValueBool(),
+		LimitViolations:    m.LimitViolations.ValueString(),
+		SchemaRef:          m.SchemaRef.ValueString(),
+		OpenAPIDirection:   m.OpenAPIDirection.ValueString(),
+		SpecFile:           m.SpecFile.ValueString(),
+		SpecDir:            m.SpecDir.ValueString(),
+		ValidationMode:     m.ValidationMode.ValueString(),
+		ValidationSeverity: severity,
+		Specs:              specs,
+	}, diags
+}
+
+// setSpecDraft records the JSON Schema draft or OpenAPI version the encoder
+// validates with.
+func (m *EncodeOptionsModel) setSpecDraft(e *itemEncoder) {
+	m.SpecDraft = types.StringNull()
+	if draft := e.specDraft(); draft != "" {
+		m.SpecDraft = types.StringValue(draft)
+	}
+}
+
+// setAppliedChanges records the changes apply_defaults and coerce_types made
+// to the items the encoder converted.
+func (m *EncodeOptionsModel) setAppliedChanges(ctx context.Context, e *itemEncoder) diag.Diagnostics {
+	if !e.opts.rewritesItems() {
+		m.AppliedChanges = types.ListNull(types.StringType)
+		return nil
+	}
+	var diags diag.Diagnostics
+	m.AppliedChanges, diags = types.ListValueFrom(ctx, types.StringType, append([]string{}, e.appliedChanges...))
+	return diags
+}
@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &DecodeFunction{}
+
+func NewDecodeFunction() function.Function {
+	return &DecodeFunction{}
+}
+
+// DecodeFunction defines the function implementation.
+type DecodeFunction struct{}
+
+func (f *DecodeFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "decode"
+}
+
+func (f *DecodeFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "DynamoDB JSON into JSON format",
+		MarkdownDescription: "Converts a DynamoDB JSON item back into plain JSON. The result is identical to the `result` attribute of the `json2dynamodb_decode` data source.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "dynamodb_json",
+				MarkdownDescription: "DynamoDB JSON String",
+			},
+		},
+		VariadicParameter: function.DynamicParameter{
+			Name:                "options",
+			MarkdownDescription: "Optional object with `set_format` (`array` or `sorted`) and `binary_format` (`base64` or `utf8`) keys. At most one may be given.",
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *DecodeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var input string
+	var options []types.Dynamic
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &input, &options))
+
+	if resp.Error != nil {
+		return
+	}
+
+	if len(options) > 1 {
+		resp.Error = function.NewArgumentFuncError(2, "At most one options object may be given.")
+		return
+	}
+
+	var opts decodeOptions
+	if len(options) == 1 {
+		m, err := functionOptions(options[0])
+		if err == nil {
+			err = checkOptions(m, "set_format", "binary_format")
+		}
+		if err == nil {
+			opts.SetFormat, err = optionString(m, "set_format")
+		}
+		if err == nil {
+			opts.BinaryFormat, err = optionString(m, "binary_format")
+		}
+		if err != nil {
+			resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("Invalid options: %s.", err))
+			return
+		}
+	}
+
+	result, diags := decodeJSON(input, opts)
+	resp.Error = function.FuncErrorFromDiags(ctx, diags)
+
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, result))
+}
@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+const testDecodeFunctionConfig_roundTrip = `
+locals {
+  item = {
+    name = "briansenvtest"
+    default_attributes = {
+      wildfly = {
+        config = {
+          abc = 123
+        }
+      }
+    }
+  }
+}
+
+output "roundtrip" {
+  value = provider::json2dynamodb::decode(provider::json2dynamodb::encode(jsonencode(local.item))) == jsonencode(local.item)
+}
+
+output "sorted" {
+  value = provider::json2dynamodb::decode(jsonencode({ tags = { SS = ["b", "a"] } }), { set_format = "sorted" })
+}
+`
+
+func TestDecodeFunction_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		Steps: []resource.TestStep{
+			{
+				Config: testDecodeFunctionConfig_roundTrip,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("roundtrip", "true"),
+					resource.TestCheckOutput("sorted", `{"tags":["a","b"]}`),
+				),
+			},
+		},
+	})
+}
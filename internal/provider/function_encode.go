@@ -0,0 +1,152 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &EncodeFunction{}
+
+func NewEncodeFunction() function.Function {
+	return &EncodeFunction{}
+}
+
+// EncodeFunction defines the function implementation.
+type EncodeFunction struct{}
+
+func (f *EncodeFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "encode"
+}
+
+func (f *EncodeFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "JSON into DynamoDB JSON format",
+		MarkdownDescription: "Converts a JSON string into DynamoDB JSON, optionally validating it against a JSON Schema first. The result is identical to the `result` attribute of the `json2dynamodb` data source, but is known at plan time.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "json",
+				MarkdownDescription: "JSON String",
+			},
+		},
+		VariadicParameter: function.DynamicParameter{
+			Name:                "options",
+			MarkdownDescription: "Either the JSON Schema in JSON format to validate the JSON against, or an object with `spec`, `schema_dialect`, `type_hints`, `schema_types`, `epoch_date_times`, `apply_defaults`, `coerce_types`, `limit_violations`, `schema_ref`, `openapi_direction`, `validation_mode` and `validation_severity` keys matching the `json2dynamodb` data source arguments. At most one may be given. As functions cannot report warnings, `\"warn\"` is rejected as a `limit_violations`, `validation_mode` or `validation_severity` value; use the data source to get warnings.",
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *EncodeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var input string
+	var options []types.Dynamic
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &input, &options))
+
+	if resp.Error != nil {
+		return
+	}
+
+	if len(options) > 1 {
+		resp.Error = function.NewArgumentFuncError(2, "At most one options argument may be given.")
+		return
+	}
+
+	var opts encodeOptions
+	if len(options) == 1 {
+		var err error
+		if opts, err = encodeFunctionOptions(options[0]); err != nil {
+			resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("Invalid options: %s.", err))
+			return
+		}
+	}
+
+	result, diags := encodeJSON(input, opts)
+	resp.Error = function.FuncErrorFromDiags(ctx, diags)
+
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, result))
+}
+
+// encodeFunctionOptions reads the encode options argument. A plain string is
+// accepted as the spec for compatibility with encode(json, spec).
+func encodeFunctionOptions(v types.Dynamic) (encodeOptions, error) {
+	var opts encodeOptions
+
+	if s, ok := v.UnderlyingValue().(types.String); ok {
+		opts.Spec = s.ValueString()
+		return opts, nil
+	}
+
+	m, err := functionOptions(v)
+	if err == nil {
+		err = checkOptions(m, "spec", "schema_dialect", "type_hints", "schema_types", "epoch_date_times", "apply_defaults", "coerce_types", "limit_violations", "schema_ref", "openapi_direction", "validation_mode", "validation_severity")
+	}
+	if err == nil {
+		opts.Spec, err = optionString(m, "spec")
+	}
+	if err == nil {
+		opts.SchemaDialect, err = optionString(m, "schema_dialect")
+	}
+	if err == nil {
+		opts.TypeHints, err = optionStringMap(m, "type_hints")
+	}
+	if err == nil {
+		opts.SchemaTypes, err = optionBool(m, "schema_types")
+	}
+	if err == nil {
+		opts.EpochDateTimes, err = optionBool(m, "epoch_date_times")
+	}
+	if err == nil {
+		opts.ApplyDefaults, err = optionBool(m, "apply_defaults")
+	}
+	if err == nil {
+		opts.CoerceTypes, err = optionBool(m, "coerce_types")
+	}
+	if err == nil {
+		opts.LimitViolations, err = optionString(m, "limit_violations")
+	}
+	if err == nil {
+		opts.SchemaRef, err = optionString(m, "schema_ref")
+	}
+	if err == nil {
+		opts.OpenAPIDirection, err = optionString(m, "openapi_direction")
+	}
+	if err == nil {
+		opts.ValidationMode, err = optionString(m, "validation_mode")
+	}
+	if err == nil {
+		opts.ValidationSeverity, err = optionStringMap(m, "validation_severity")
+	}
+	if err == nil {
+		err = checkFunctionSeverities(opts)
+	}
+	return opts, err
+}
+
+// checkFunctionSeverities rejects the options that turn errors into
+// warnings, as a function cannot report warnings and would silently drop
+// them.
+func checkFunctionSeverities(opts encodeOptions) error {
+	if opts.LimitViolations == limitViolationsWarn {
+		return fmt.Errorf("limit_violations %q is not supported, as functions cannot report warnings", limitViolationsWarn)
+	}
+	if opts.ValidationMode == validationModeWarn {
+		return fmt.Errorf("validation_mode %q is not supported, as functions cannot report warnings", validationModeWarn)
+	}
+	for _, k := range slices.Sorted(maps.Keys(opts.ValidationSeverity)) {
+		if opts.ValidationSeverity[k] == validationModeWarn {
+			return fmt.Errorf("validation_severity %q of %q is not supported, as functions cannot report warnings", validationModeWarn, k)
+		}
+	}
+	return nil
+}
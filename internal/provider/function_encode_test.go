@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+const testEncodeFunctionConfig_basic = `
+output "ddbjson" {
+  value = provider::json2dynamodb::encode(jsonencode({
+    name = "briansenvtest"
+    default_attributes = {
+      wildfly = {
+        config = {
+          abc = 123
+        }
+      }
+    }
+    tags = ["a", "b"]
+  }))
+}
+`
+
+const testEncodeFunctionConfig_spec = `
+output "ddbjson" {
+  value = provider::json2dynamodb::encode(
+    jsonencode({ name = "briansenvtest" }),
+    jsonencode({
+      type     = "object"
+      required = ["name"]
+      properties = {
+        name = { type = "string", pattern = "^[a-z]+$" }
+      }
+    })
+  )
+}
+`
+
+const testEncodeFunctionConfig_options = `
+output "ddbjson" {
+  value = provider::json2dynamodb::encode(jsonencode({ tags = ["a", "b"] }), {
+    type_hints = { "/tags" = "SS" }
+  })
+}
+`
+
+const testEncodeFunctionConfig_invalid = `
+output "ddbjson" {
+  value = provider::json2dynamodb::encode(
+    jsonencode({ name = "Not Valid" }),
+    jsonencode({
+      type       = "object"
+      properties = { name = { type = "string", pattern = "^[a-z]+$" } }
+    })
+  )
+}
+`
+
+const testEncodeFunctionConfig_syntaxError = `
+output "ddbjson" {
+  value = provider::json2dynamodb::encode(<<-EOF
+    {
+      "name": "briansenvtest",
+      "tags": [a]
+    }
+    EOF
+  )
+}
+`
+
+func TestEncodeFunction_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		Steps: []resource.TestStep{
+			{
+				Config: testEncodeFunctionConfig_basic,
+				Check: resource.TestCheckOutput("ddbjson",
+					`{"default_attributes":{"M":{"wildfly":{"M":{"config":{"M":{"abc":{"N":"123"}}}}}}},"name":{"S":"briansenvtest"},"tags":{"L":[{"S":"a"},{"S":"b"}]}}`,
+				),
+			},
+			{
+				Config: testEncodeFunctionConfig_spec,
+				Check:  resource.TestCheckOutput("ddbjson", `{"name":{"S":"briansenvtest"}}`),
+			},
+			{
+				Config: testEncodeFunctionConfig_options,
+				Check:  resource.TestCheckOutput("ddbjson", `{"tags":{"SS":["a","b"]}}`),
+			},
+			{
+				Config:      testEncodeFunctionConfig_invalid,
+				ExpectError: regexp.MustCompile(`JSON Spec\s+Validation Failure`),
+			},
+			{
+				Config: `
+output "ddbjson" {
+  value = provider::json2dynamodb::encode(jsonencode({ id = "a" }), {
+    spec            = jsonencode({ type = "object" })
+    validation_mode = "warn"
+  })
+}
+`,
+				ExpectError: regexp.MustCompile(`validation_mode\s+"warn"\s+is\s+not\s+supported`),
+			},
+			{
+				Config: `
+output "ddbjson" {
+  value = provider::json2dynamodb::encode(jsonencode({ id = "a" }), { limit_violations = "warn" })
+}
+`,
+				ExpectError: regexp.MustCompile(`limit_violations\s+"warn"\s+is\s+not\s+supported`),
+			},
+			{
+				Config:      testEncodeFunctionConfig_syntaxError,
+				ExpectError: regexp.MustCompile(`line 3, column 12: invalid character 'a'`),
+			},
+		},
+	})
+}
@@ -0,0 +1,156 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// functionOptions reads a function options argument, given as an object or a
+// map, into Go values. Null attributes are omitted.
+func functionOptions(v types.Dynamic) (map[string]interface{}, error) {
+	if v.IsNull() || v.IsUnderlyingValueNull() {
+		return nil, nil
+	}
+	switch v.UnderlyingValue().(type) {
+	case types.Object, types.Map:
+	default:
+		return nil, fmt.Errorf("options must be an object, got: %s", v.UnderlyingValue().Type(context.Background()))
+	}
+
+	opts, err := attrValueToInterface(v.UnderlyingValue())
+	if err != nil {
+		return nil, err
+	}
+	return opts.(map[string]interface{}), nil
+}
+
+// attrValueToInterface converts a Terraform value into the Go values produced
+// by decoding JSON with json.Number: strings, bools, json.Number, slices and
+// maps. Null values become nil and null object attributes are omitted.
+func attrValueToInterface(v attr.Value) (interface{}, error) {
+	if v == nil || v.IsNull() {
+		return nil, nil
+	}
+	if v.IsUnknown() {
+		return nil, fmt.Errorf("value is not yet known")
+	}
+
+	switch uv := v.(type) {
+	case basetypes.StringValuable:
+		s, diags := uv.ToStringValue(context.Background())
+		if diags.HasError() {
+			return nil, fmt.Errorf("converting string: %s", diags.Errors()[0].Detail())
+		}
+		return s.ValueString(), nil
+	case types.Bool:
+		return uv.ValueBool(), nil
+	case types.Number:
+		return json.Number(uv.ValueBigFloat().Text('g', -1)), nil
+	case types.Dynamic:
+		return attrValueToInterface(uv.UnderlyingValue())
+	case types.List:
+		return attrValuesToInterface(uv.Elements())
+	case types.Set:
+		return attrValuesToInterface(uv.Elements())
+	case types.Tuple:
+		return attrValuesToInterface(uv.Elements())
+	case types.Map:
+		return attrValueMapToInterface(uv.Elements())
+	case types.Object:
+		return attrValueMapToInterface(uv.Attributes())
+	default:
+		return nil, fmt.Errorf("unsupported value type %s", v.Type(context.Background()))
+	}
+}
+
+func attrValuesToInterface(elements []attr.Value) (interface{}, error) {
+	l := make([]interface{}, 0, len(elements))
+	for i, e := range elements {
+		ev, err := attrValueToInterface(e)
+		if err != nil {
+			return nil, fmt.Errorf("[%d]: %w", i, err)
+		}
+		l = append(l, ev)
+	}
+	return l, nil
+}
+
+func attrValueMapToInterface(elements map[string]attr.Value) (interface{}, error) {
+	m := make(map[string]interface{}, len(elements))
+	for k, e := range elements {
+		if e.IsNull() {
+			continue
+		}
+		ev, err := attrValueToInterface(e)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", k, err)
+		}
+		m[k] = ev
+	}
+	return m, nil
+}
+
+// optionString returns the string option with the given key, if set.
+func optionString(opts map[string]interface{}, key string) (string, error) {
+	v, ok := opts[key]
+	if !ok {
+		return "", nil
+	}
+	s, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("option %q must be a string, got: %s", key, jsonTypeName(v))
+	}
+	return s, nil
+}
+
+// optionBool returns the bool option with the given key, if set.
+func optionBool(opts map[string]interface{}, key string) (bool, error) {
+	v, ok := opts[key]
+	if !ok {
+		return false, nil
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return false, fmt.Errorf("option %q must be a bool, got: %s", key, jsonTypeName(v))
+	}
+	return b, nil
+}
+
+// optionStringMap returns the map of strings option with the given key, if set.
+func optionStringMap(opts map[string]interface{}, key string) (map[string]string, error) {
+	v, ok := opts[key]
+	if !ok {
+		return nil, nil
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("option %q must be a map of strings, got: %s", key, jsonTypeName(v))
+	}
+	sm := make(map[string]string, len(m))
+	for k, e := range m {
+		s, ok := e.(string)
+		if !ok {
+			return nil, fmt.Errorf("option %q must be a map of strings, got %s for %q", key, jsonTypeName(e), k)
+		}
+		sm[k] = s
+	}
+	return sm, nil
+}
+
+// checkOptions reports the first option that is not one of the supported keys.
+func checkOptions(opts map[string]interface{}, supported ...string) error {
+	for _, k := range slices.Sorted(maps.Keys(opts)) {
+		if !slices.Contains(supported, k) {
+			return fmt.Errorf("unsupported option %q, expected one of: %s", k, strings.Join(supported, ", "))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// ionVersionMarker starts an Amazon Ion text document.
+const ionVersionMarker = "$ion_1_0"
+
+// SerializeIonItems renders items in the Amazon Ion text format used by
+// DynamoDB exports to and imports from S3: one {Item:{...}} struct per line.
+func SerializeIonItems(items []map[string]types.AttributeValue) (string, error) {
+	var sb strings.Builder
+	sb.WriteString(ionVersionMarker)
+	sb.WriteByte('\n')
+	for _, item := range items {
+		sb.WriteString("{Item:")
+		if err := serializeIonMap(&sb, item); err != nil {
+			return "", err
+		}
+		sb.WriteString("}\n")
+	}
+	return sb.String(), nil
+}
+
+// serializeIonAttributeValue writes an attribute value as Ion, following the
+// DynamoDB type mapping: numbers are decimals, binary values are blobs and
+// sets are lists annotated with $dynamodb_SS, $dynamodb_NS or $dynamodb_BS.
+func serializeIonAttributeValue(sb *strings.Builder, v types.AttributeValue) error {
+	switch uv := v.(type) {
+	case *types.AttributeValueMemberB:
+		serializeIonBlob(sb, uv.Value)
+
+	case *types.AttributeValueMemberBOOL:
+		fmt.Fprint(sb, uv.Value)
+
+	case *types.AttributeValueMemberBS:
+		sb.WriteString("$dynamodb_BS::[")
+		for i, b := range uv.Value {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			serializeIonBlob(sb, b)
+		}
+		sb.WriteByte(']')
+
+	case *types.AttributeValueMemberL:
+		sb.WriteByte('[')
+		for i, e := range uv.Value {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			if err := serializeIonAttributeValue(sb, e); err != nil {
+				return err
+			}
+		}
+		sb.WriteByte(']')
+
+	case *types.AttributeValueMemberM:
+		return serializeIonMap(sb, uv.Value)
+
+	case *types.AttributeValueMemberN:
+		d, err := ionDecimal(uv.Value)
+		if err != nil {
+			return err
+		}
+		sb.WriteString(d)
+
+	case *types.AttributeValueMemberNS:
+		sb.WriteString("$dynamodb_NS::[")
+		for i, n := range uv.Value {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			d, err := ionDecimal(n)
+			if err != nil {
+				return err
+			}
+			sb.WriteString(d)
+		}
+		sb.WriteByte(']')
+
+	case *types.AttributeValueMemberNULL:
+		sb.WriteString("null")
+
+	case *types.AttributeValueMemberS:
+		serializeIonString(sb, uv.Value)
+
+	case *types.AttributeValueMemberSS:
+		sb.WriteString("$dynamodb_SS::[")
+		for i, s := range uv.Value {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			serializeIonString(sb, s)
+		}
+		sb.WriteByte(']')
+
+	default:
+		return fmt.Errorf("attempted to serialize unknown member type %T for union %T", uv, v)
+	}
+	return nil
+}
+
+func serializeIonMap(sb *strings.Builder, m map[string]types.AttributeValue) error {
+	sb.WriteByte('{')
+	for i, k := range slices.Sorted(maps.Keys(m)) {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		serializeIonString(sb, k)
+		sb.WriteByte(':')
+		if err := serializeIonAttributeValue(sb, m[k]); err != nil {
+			return fmt.Errorf("%s: %w", k, err)
+		}
+	}
+	sb.WriteByte('}')
+	return nil
+}
+
+// serializeIonString writes a quoted Ion string. The JSON string escapes
+// produced by encoding/json are a subset of the Ion ones.
+func serializeIonString(sb *strings.Builder, s string) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+	sb.Write(bytes.TrimSuffix(buf.Bytes(), []byte("\n")))
+}
+
+func serializeIonBlob(sb *strings.Builder, b []byte) {
+	sb.WriteString("{{")
+	sb.WriteString(base64.StdEncoding.EncodeToString(b))
+	sb.WriteString("}}")
+}
+
+// ionDecimal converts a DynamoDB number into an Ion decimal literal. Ion reads
+// an "e" exponent as a float and a plain integer as an int, so the exponent
+// is written with "d" and integers get a trailing ".".
+func ionDecimal(n string) (string, error) {
+	if !jsonNumberPattern.MatchString(n) {
+		return "", fmt.Errorf("invalid number %q", n)
+	}
+	if i := strings.IndexAny(n, "eE"); i >= 0 {
+		return n[:i] + "d" + strings.TrimPrefix(n[i+1:], "+"), nil
+	}
+	if !strings.Contains(n, ".") {
+		return n + ".", nil
+	}
+	return n, nil
+}
@@ -0,0 +1,180 @@
+package provider
+
+import (
+	"fmt"
+	"maps"
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// DynamoDB item limits.
+const (
+	// dynamoDBMaxItemSize is the maximum size of an item in bytes.
+	dynamoDBMaxItemSize = 400 * 1024
+	// dynamoDBMaxNestingDepth is the maximum number of nested list and map
+	// levels.
+	dynamoDBMaxNestingDepth = 32
+
+	// writeCapacityUnitSize and readCapacityUnitSize are the item sizes one
+	// write and one strongly consistent read capacity unit cover.
+	writeCapacityUnitSize = 1024
+	readCapacityUnitSize  = 4 * 1024
+)
+
+// How item limit violations are reported.
+const (
+	limitViolationsError = "error"
+	limitViolationsWarn  = "warn"
+)
+
+// itemSize returns the size DynamoDB bills for an item: the UTF-8 length of
+// each attribute name plus the size of its value.
+func itemSize(item map[string]types.AttributeValue) int64 {
+	var size int64
+	for k, v := range item {
+		size += int64(len(k)) + attributeValueSize(v)
+	}
+	return size
+}
+
+// attributeValueSize returns the size of an attribute value by DynamoDB's
+// rules: strings and binary values by length, numbers by significant digits,
+// booleans and nulls one byte, lists and maps 3 bytes plus 1 byte and the
+// size of each element, and sets the size of their members.
+func attributeValueSize(v types.AttributeValue) int64 {
+	switch uv := v.(type) {
+	case *types.AttributeValueMemberB:
+		return int64(len(uv.Value))
+	case *types.AttributeValueMemberBOOL, *types.AttributeValueMemberNULL:
+		return 1
+	case *types.AttributeValueMemberBS:
+		var size int64
+		for _, b := range uv.Value {
+			size += int64(len(b))
+		}
+		return size
+	case *types.AttributeValueMemberL:
+		size := int64(3)
+		for _, e := range uv.Value {
+			size += 1 + attributeValueSize(e)
+		}
+		return size
+	case *types.AttributeValueMemberM:
+		size := int64(3)
+		for k, e := range uv.Value {
+			size += 1 + int64(len(k)) + attributeValueSize(e)
+		}
+		return size
+	case *types.AttributeValueMemberN:
+		return numberSize(uv.Value)
+	case *types.AttributeValueMemberNS:
+		var size int64
+		for _, n := range uv.Value {
+			size += numberSize(n)
+		}
+		return size
+	case *types.AttributeValueMemberS:
+		return int64(len(uv.Value))
+	case *types.AttributeValueMemberSS:
+		var size int64
+		for _, s := range uv.Value {
+			size += int64(len(s))
+		}
+		return size
+	}
+	return 0
+}
+
+// numberSize returns the size of a number: 1 byte per two significant
+// digits, plus 1 byte.
+func numberSize(n string) int64 {
+	if i := strings.IndexAny(n, "eE"); i >= 0 {
+		n = n[:i]
+	}
+	digits := strings.TrimLeft(strings.NewReplacer("-", "", "+", "", ".", "").Replace(n), "0")
+	digits = strings.TrimRight(digits, "0")
+	return int64((len(digits)+1)/2 + 1)
+}
+
+// capacityUnits returns the capacity units an operation on an item of the
+// given size consumes, for the size one unit covers.
+func capacityUnits(size, unitSize int64) int64 {
+	return max(1, (size+unitSize-1)/unitSize)
+}
+
+// itemLimitViolations returns a description of every way the item breaks a
+// DynamoDB limit that would only be reported when it is written.
+func itemLimitViolations(item map[string]types.AttributeValue) []string {
+	var violations []string
+
+	if size := itemSize(item); size > dynamoDBMaxItemSize {
+		violations = append(violations, fmt.Sprintf("the item is %d bytes, over the %d byte DynamoDB item size limit", size, dynamoDBMaxItemSize))
+	}
+	for _, k := range slices.Sorted(maps.Keys(item)) {
+		violations = append(violations, attributeLimitViolations(item[k], []string{k}, 0)...)
+	}
+	return violations
+}
+
+func attributeLimitViolations(v types.AttributeValue, segments []string, depth int) []string {
+	var violations []string
+
+	switch uv := v.(type) {
+	case *types.AttributeValueMemberL, *types.AttributeValueMemberM:
+		if depth == dynamoDBMaxNestingDepth {
+			return []string{fmt.Sprintf("at %s: nesting is deeper than the %d level DynamoDB limit", formatPointer(segments), dynamoDBMaxNestingDepth)}
+		}
+		if l, ok := uv.(*types.AttributeValueMemberL); ok {
+			for i, e := range l.Value {
+				violations = append(violations, attributeLimitViolations(e, append(slices.Clip(segments), strconv.Itoa(i)), depth+1)...)
+			}
+			break
+		}
+		m := uv.(*types.AttributeValueMemberM).Value
+		for _, k := range slices.Sorted(maps.Keys(m)) {
+			violations = append(violations, attributeLimitViolations(m[k], append(slices.Clip(segments), k), depth+1)...)
+		}
+
+	case *types.AttributeValueMemberSS:
+		violations = append(violations, setLimitViolations(segments, attributeTypeSS, uv.Value, func(s string) string { return s })...)
+	case *types.AttributeValueMemberBS:
+		violations = append(violations, setLimitViolations(segments, attributeTypeBS, uv.Value, func(b []byte) string { return string(b) })...)
+	case *types.AttributeValueMemberNS:
+		violations = append(violations, setLimitViolations(segments, attributeTypeNS, uv.Value, func(n string) string {
+			// Compare by value, so 1 and 1.0 are the same member.
+			if r, ok := new(big.Rat).SetString(n); ok {
+				return r.RatString()
+			}
+			return n
+		})...)
+	}
+	return violations
+}
+
+// setLimitViolations checks a set is not empty and its members are neither
+// empty nor duplicated, comparing members by the given key.
+func setLimitViolations[T string | []byte](segments []string, t string, members []T, key func(T) string) []string {
+	if len(members) == 0 {
+		return []string{fmt.Sprintf("at %s: %s sets cannot be empty", formatPointer(segments), t)}
+	}
+
+	var violations []string
+	seen := make(map[string]int, len(members))
+	for i, m := range members {
+		if len(m) == 0 {
+			violations = append(violations, fmt.Sprintf("at %s: [%d]: %s members cannot be empty", formatPointer(segments), i, t))
+			continue
+		}
+		k := key(m)
+		if j, dup := seen[k]; dup {
+			violations = append(violations, fmt.Sprintf("at %s: [%d]: duplicate %s member, also at [%d]", formatPointer(segments), i, t, j))
+			continue
+		}
+		seen[k] = i
+	}
+	return violations
+}
@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+func TestAttributeValueSize(t *testing.T) {
+	cases := []struct {
+		name string
+		v    types.AttributeValue
+		want int64
+	}{
+		{"string", &types.AttributeValueMemberS{Value: "héllo"}, 6},
+		{"zero", &types.AttributeValueMemberN{Value: "0"}, 1},
+		{"integer", &types.AttributeValueMemberN{Value: "12345"}, 4},
+		{"trailing zeros", &types.AttributeValueMemberN{Value: "1000"}, 2},
+		{"decimal", &types.AttributeValueMemberN{Value: "-0.00125"}, 3},
+		{"exponent", &types.AttributeValueMemberN{Value: "1.5E+10"}, 2},
+		{"binary", &types.AttributeValueMemberB{Value: []byte{1, 2, 3}}, 3},
+		{"bool", &types.AttributeValueMemberBOOL{Value: true}, 1},
+		{"null", &types.AttributeValueMemberNULL{Value: true}, 1},
+		{"empty list", &types.AttributeValueMemberL{}, 3},
+		{"list", &types.AttributeValueMemberL{Value: []types.AttributeValue{
+			&types.AttributeValueMemberS{Value: "ab"},
+			&types.AttributeValueMemberBOOL{Value: false},
+		}}, 8},
+		{"map", &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+			"key": &types.AttributeValueMemberS{Value: "ab"},
+		}}, 9},
+		{"string set", &types.AttributeValueMemberSS{Value: []string{"a", "bc"}}, 3},
+		{"number set", &types.AttributeValueMemberNS{Value: []string{"1", "123"}}, 5},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := attributeValueSize(tc.v); got != tc.want {
+				t.Errorf("attributeValueSize() = %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// defaultSchemaDialect applies to specs without $schema. The spec was
+// originally read as an OpenAPI Schema, which is based on draft 4.
+const defaultSchemaDialect = "draft-04"
+
+// schemaDialects are the supported JSON Schema drafts in order, named as
+// schema_dialect accepts them and spec_draft reports them.
+var schemaDialects = []struct {
+	Name  string
+	Draft *jsonschema.Draft
+	// Version is the version number jsonschema.Schema.DraftVersion holds.
+	Version int
+}{
+	{"draft-04", jsonschema.Draft4, 4},
+	{"draft-06", jsonschema.Draft6, 6},
+	{"draft-07", jsonschema.Draft7, 7},
+	{"2019-09", jsonschema.Draft2019, 2019},
+	{"2020-12", jsonschema.Draft2020, 2020},
+}
+
+// schemaDialectNames returns the names of the supported dialects.
+func schemaDialectNames() []string {
+	names := make([]string, 0, len(schemaDialects))
+	for _, d := range schemaDialects {
+		names = append(names, d.Name)
+	}
+	return names
+}
+
+// specURL is the location an inline spec is compiled at, when it is not
+// placed in spec_dir. Only the spec itself and the JSON Schema meta-schemas
+// can be referenced.
+const specURL = "urn:json2dynamodb:spec"
+
+// compileJSONSchema compiles a JSON Schema for validation. The draft is taken
+// from $schema, or from dialect when the spec has no $schema.
+func compileJSONSchema(src *specSource, dialect string) (*jsonschema.Schema, error) {
+	if dialect == "" {
+		dialect = defaultSchemaDialect
+	}
+	var draft *jsonschema.Draft
+	for _, d := range schemaDialects {
+		if d.Name == dialect {
+			draft = d.Draft
+		}
+	}
+	if draft == nil {
+		return nil, fmt.Errorf("unsupported schema dialect %q, expected one of %s", dialect, strings.Join(schemaDialectNames(), ", "))
+	}
+
+	doc, err := src.Load(src.url.String())
+	if err != nil {
+		return nil, err
+	}
+
+	c := jsonschema.NewCompiler()
+	c.DefaultDraft(draft)
+	c.AssertFormat()
+	c.UseLoader(src)
+	if err := c.AddResource(src.url.String(), doc); err != nil {
+		return nil, err
+	}
+	return c.Compile(src.url.String())
+}
+
+// jsonSchemaValidator validates items against a compiled JSON Schema.
+type jsonSchemaValidator struct {
+	*jsonschema.Schema
+}
+
+func (v jsonSchemaValidator) Validate(item interface{}) []specViolation {
+	if err := v.Schema.Validate(item); err != nil {
+		return jsonSchemaViolations(err)
+	}
+	return nil
+}
+
+func (v jsonSchemaValidator) Draft() string {
+	return schemaDialect(v.Schema)
+}
+
+// schemaDialect returns the name of the draft a schema was compiled with.
+func schemaDialect(s *jsonschema.Schema) string {
+	for _, d := range schemaDialects {
+		if d.Version == s.DraftVersion {
+			return d.Name
+		}
+	}
+	return fmt.Sprint(s.DraftVersion)
+}
@@ -0,0 +1,282 @@
+package provider
+
+import (
+	"fmt"
+
+	awstypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Maximum sizes of key attribute values in bytes.
+const (
+	dynamoDBMaxHashKeySize  = 2048
+	dynamoDBMaxRangeKeySize = 1024
+)
+
+// keyTypes are the scalar types a key attribute may have.
+var keyTypes = []string{attributeTypeS, attributeTypeN, attributeTypeB}
+
+// KeySchemaModel describes the key_schema block: the primary key of a table
+// and the keys of its secondary indexes.
+type KeySchemaModel struct {
+	HashKey              types.String                `tfsdk:"hash_key"`
+	HashKeyType          types.String                `tfsdk:"hash_key_type"`
+	RangeKey             types.String                `tfsdk:"range_key"`
+	RangeKeyType         types.String                `tfsdk:"range_key_type"`
+	GlobalSecondaryIndex []GlobalSecondaryIndexModel `tfsdk:"global_secondary_index"`
+	LocalSecondaryIndex  []LocalSecondaryIndexModel  `tfsdk:"local_secondary_index"`
+}
+
+// GlobalSecondaryIndexModel describes the keys of a global secondary index.
+type GlobalSecondaryIndexModel struct {
+	Name         types.String `tfsdk:"name"`
+	HashKey      types.String `tfsdk:"hash_key"`
+	HashKeyType  types.String `tfsdk:"hash_key_type"`
+	RangeKey     types.String `tfsdk:"range_key"`
+	RangeKeyType types.String `tfsdk:"range_key_type"`
+}
+
+// LocalSecondaryIndexModel describes the keys of a local secondary index,
+// which shares the hash key of the table.
+type LocalSecondaryIndexModel struct {
+	Name         types.String `tfsdk:"name"`
+	RangeKey     types.String `tfsdk:"range_key"`
+	RangeKeyType types.String `tfsdk:"range_key_type"`
+}
+
+// keySchemaBlock returns the key_schema block.
+func keySchemaBlock() schema.Block {
+	keyTypeDescription := fmt.Sprintf("one of `%s` (the default), `%s` or `%s`", attributeTypeS, attributeTypeN, attributeTypeB)
+	keyAttributes := func(hashKey, rangeKey bool) map[string]schema.Attribute {
+		return map[string]schema.Attribute{
+			"hash_key": schema.StringAttribute{
+				MarkdownDescription: "Name of the hash (partition) key attribute",
+				Required:            hashKey,
+				Optional:            !hashKey,
+			},
+			"hash_key_type": schema.StringAttribute{
+				MarkdownDescription: "Type of the hash key attribute: " + keyTypeDescription,
+				Optional:            true,
+			},
+			"range_key": schema.StringAttribute{
+				MarkdownDescription: "Name of the range (sort) key attribute",
+				Required:            rangeKey,
+				Optional:            !rangeKey,
+			},
+			"range_key_type": schema.StringAttribute{
+				MarkdownDescription: "Type of the range key attribute: " + keyTypeDescription,
+				Optional:            true,
+			},
+		}
+	}
+	indexAttributes := func(hashKey, rangeKey bool) map[string]schema.Attribute {
+		attributes := keyAttributes(hashKey, rangeKey)
+		attributes["name"] = schema.StringAttribute{
+			MarkdownDescription: "Name of the index",
+			Required:            true,
+		}
+		return attributes
+	}
+	lsiAttributes := indexAttributes(false, true)
+	delete(lsiAttributes, "hash_key")
+	delete(lsiAttributes, "hash_key_type")
+
+	return schema.SingleNestedBlock{
+		MarkdownDescription: "Key schema of the table the item is written to. The item must have every primary key attribute, and every key attribute present must have the declared type, must not be empty and must fit the DynamoDB key size limits. Index keys may be missing, as indexes are sparse.",
+		Attributes:          keyAttributes(false, false),
+		Blocks: map[string]schema.Block{
+			"global_secondary_index": schema.ListNestedBlock{
+				MarkdownDescription: "Keys of a global secondary index",
+				NestedObject:        schema.NestedBlockObject{Attributes: indexAttributes(true, false)},
+			},
+			"local_secondary_index": schema.ListNestedBlock{
+				MarkdownDescription: "Keys of a local secondary index",
+				NestedObject:        schema.NestedBlockObject{Attributes: lsiAttributes},
+			},
+		},
+	}
+}
+
+// keyAttribute is a key attribute of the table or of one of its indexes.
+type keyAttribute struct {
+	Name    string
+	Type    string
+	MaxSize int
+	// Primary is set for the keys of the table, which every item must have.
+	Primary bool
+	// Source names the key in error messages, e.g. "hash_key".
+	Source string
+}
+
+// keyAttributes lists the key attributes of the schema, checking their
+// types. The primary keys come first, hash key before range key.
+func (m *KeySchemaModel) keyAttributes() ([]keyAttribute, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var keys []keyAttribute
+
+	root := path.Root("key_schema")
+	add := func(name, keyType types.String, maxSize int, primary bool, source string, p path.Path) {
+		if name.ValueString() == "" {
+			return
+		}
+		t := keyType.ValueString()
+		switch t {
+		case "":
+			t = attributeTypeS
+		case attributeTypeS, attributeTypeN, attributeTypeB:
+		default:
+			diags.AddAttributeError(
+				p.AtName(source+"_type"),
+				"Invalid Key Type",
+				fmt.Sprintf("Expected one of %q, %q or %q, got: %q.", keyTypes[0], keyTypes[1], keyTypes[2], t),
+			)
+			return
+		}
+		for _, k := range keys {
+			if k.Name == name.ValueString() && k.Type != t {
+				diags.AddAttributeError(
+					p.AtName(source+"_type"),
+					"Conflicting Key Type",
+					fmt.Sprintf("Key attribute %q is declared as both %s and %s.", k.Name, k.Type, t),
+				)
+				return
+			}
+		}
+		keys = append(keys, keyAttribute{Name: name.ValueString(), Type: t, MaxSize: maxSize, Primary: primary, Source: source})
+	}
+
+	if m.HashKey.ValueString() == "" {
+		diags.AddAttributeError(
+			root.AtName("hash_key"),
+			"Missing Hash Key",
+			"A key_schema must name the hash key attribute of the table.",
+		)
+		return nil, diags
+	}
+	add(m.HashKey, m.HashKeyType, dynamoDBMaxHashKeySize, true, "hash_key", root)
+	add(m.RangeKey, m.RangeKeyType, dynamoDBMaxRangeKeySize, true, "range_key", root)
+	for i, gsi := range m.GlobalSecondaryIndex {
+		p := root.AtName("global_secondary_index").AtListIndex(i)
+		add(gsi.HashKey, gsi.HashKeyType, dynamoDBMaxHashKeySize, false, "hash_key", p)
+		add(gsi.RangeKey, gsi.RangeKeyType, dynamoDBMaxRangeKeySize, false, "range_key", p)
+	}
+	for i, lsi := range m.LocalSecondaryIndex {
+		p := root.AtName("local_secondary_index").AtListIndex(i)
+		add(lsi.RangeKey, lsi.RangeKeyType, dynamoDBMaxRangeKeySize, false, "range_key", p)
+	}
+	return keys, diags
+}
+
+// primaryKey returns the primary key attributes of an item, checking every
+// key attribute of the table and its indexes. Size violations are reported
+// as limitViolations says, everything else is an error.
+func (m *KeySchemaModel) primaryKey(item map[string]awstypes.AttributeValue, limitViolations string) (map[string]awstypes.AttributeValue, diag.Diagnostics) {
+	keys, diags := m.keyAttributes()
+
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	key := make(map[string]awstypes.AttributeValue, 2)
+	seen := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		v, ok := item[k.Name]
+		if !ok {
+			if k.Primary {
+				diags.AddAttributeError(
+					path.Root("json"),
+					"Missing Key Attribute",
+					fmt.Sprintf("The item has no %s attribute %q, which every item of the table must have.", k.Source, k.Name),
+				)
+			}
+			continue
+		}
+		if k.Primary {
+			key[k.Name] = v
+		}
+		if seen[k.Name] {
+			continue
+		}
+		seen[k.Name] = true
+
+		size, err := keyValueSize(v, k.Type)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("json"),
+				"Invalid Key Attribute",
+				fmt.Sprintf("Key attribute %q: %s.", k.Name, err),
+			)
+			continue
+		}
+		if size > k.MaxSize {
+			summary := "DynamoDB Item Limit Exceeded"
+			detail := fmt.Sprintf("DynamoDB would reject this item when it is written.\n\nError: %s attribute %q is %d bytes, over the %d byte DynamoDB limit", k.Source, k.Name, size, k.MaxSize)
+			if limitViolations == limitViolationsWarn {
+				diags.AddAttributeWarning(path.Root("json"), summary, detail)
+			} else {
+				diags.AddAttributeError(path.Root("json"), summary, detail)
+			}
+		}
+	}
+
+	if diags.HasError() {
+		return nil, diags
+	}
+	return key, diags
+}
+
+// keyValueSize checks a key value has the given type and is not empty, and
+// returns its size.
+func keyValueSize(v awstypes.AttributeValue, keyType string) (int, error) {
+	switch uv := v.(type) {
+	case *awstypes.AttributeValueMemberS:
+		if keyType == attributeTypeS {
+			if uv.Value == "" {
+				return 0, fmt.Errorf("key values cannot be empty strings")
+			}
+			return len(uv.Value), nil
+		}
+	case *awstypes.AttributeValueMemberN:
+		if keyType == attributeTypeN {
+			return int(numberSize(uv.Value)), nil
+		}
+	case *awstypes.AttributeValueMemberB:
+		if keyType == attributeTypeB {
+			if len(uv.Value) == 0 {
+				return 0, fmt.Errorf("key values cannot be empty binary values")
+			}
+			return len(uv.Value), nil
+		}
+	}
+	return 0, fmt.Errorf("expected type %s, got %s", keyType, attributeValueType(v))
+}
+
+// attributeValueType returns the DynamoDB type descriptor of an attribute value.
+func attributeValueType(v awstypes.AttributeValue) string {
+	switch v.(type) {
+	case *awstypes.AttributeValueMemberB:
+		return attributeTypeB
+	case *awstypes.AttributeValueMemberBOOL:
+		return "BOOL"
+	case *awstypes.AttributeValueMemberBS:
+		return attributeTypeBS
+	case *awstypes.AttributeValueMemberL:
+		return "L"
+	case *awstypes.AttributeValueMemberM:
+		return "M"
+	case *awstypes.AttributeValueMemberN:
+		return attributeTypeN
+	case *awstypes.AttributeValueMemberNS:
+		return attributeTypeNS
+	case *awstypes.AttributeValueMemberNULL:
+		return attributeTypeNULL
+	case *awstypes.AttributeValueMemberS:
+		return attributeTypeS
+	case *awstypes.AttributeValueMemberSS:
+		return attributeTypeSS
+	}
+	return fmt.Sprintf("%T", v)
+}
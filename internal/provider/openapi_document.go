@@ -0,0 +1,228 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-openapi/spec"
+)
+
+// openAPISchemaRefPrefix is the prefix of the schema_ref of a component schema.
+const openAPISchemaRefPrefix = "#/components/schemas/"
+
+// Which side of an API exchange items are validated as. A request rejects
+// readOnly properties and a response rejects writeOnly properties.
+const (
+	openAPIDirectionRequest  = "request"
+	openAPIDirectionResponse = "response"
+)
+
+// openAPIDocument is an OpenAPI 3.x document with its $refs resolved.
+type openAPIDocument struct {
+	doc *openapi3.T
+	src *specSource
+}
+
+// loadOpenAPIDocument parses an OpenAPI 3.x document in JSON or YAML. Other
+// documents can only be referenced when the spec is read from spec_file or
+// placed in spec_dir.
+func loadOpenAPIDocument(src *specSource) (*openAPIDocument, error) {
+	loader := openapi3.NewLoader()
+
+	var doc *openapi3.T
+	var err error
+	if src.external {
+		loader.IsExternalRefsAllowed = true
+		loader.ReadFromURIFunc = func(_ *openapi3.Loader, u *url.URL) ([]byte, error) {
+			if u.Scheme == "" {
+				u = fileURL(u.Path)
+			}
+			return src.read(u)
+		}
+		doc, err = loader.LoadFromDataWithPath(src.data, &url.URL{Path: src.url.Path})
+	} else {
+		doc, err = loader.LoadFromData(src.data)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		return nil, fmt.Errorf("expected an OpenAPI 3.x document, got openapi: %q", doc.OpenAPI)
+	}
+
+	if doc.Components != nil {
+		for _, s := range doc.Components.Schemas {
+			if s.Value != nil {
+				implicitDiscriminatorMapping(s.Value)
+			}
+		}
+	}
+	return &openAPIDocument{doc: doc, src: src}, nil
+}
+
+// implicitDiscriminatorMapping maps the name of each component schema in the
+// oneOf or anyOf of a discriminator to its reference, as OpenAPI implies
+// without an explicit mapping. openapi3 only selects the schema to validate
+// against from explicit mappings.
+func implicitDiscriminatorMapping(s *openapi3.Schema) {
+	if s.Discriminator == nil {
+		return
+	}
+
+	mapped := make(map[string]bool, len(s.Discriminator.Mapping))
+	for _, m := range s.Discriminator.Mapping {
+		mapped[m.Ref] = true
+	}
+	for _, r := range append(slices.Clip(s.OneOf), s.AnyOf...) {
+		name, ok := strings.CutPrefix(r.Ref, openAPISchemaRefPrefix)
+		if !ok || mapped[r.Ref] {
+			continue
+		}
+		if _, ok := s.Discriminator.Mapping[name]; ok {
+			continue
+		}
+		if s.Discriminator.Mapping == nil {
+			s.Discriminator.Mapping = make(openapi3.StringMap[openapi3.MappingRef])
+		}
+		s.Discriminator.Mapping[name] = openapi3.MappingRef{Ref: r.Ref}
+	}
+}
+
+// schemaName returns the component name a schema_ref points to.
+func (d *openAPIDocument) schemaName(ref string) (string, error) {
+	name, ok := strings.CutPrefix(ref, openAPISchemaRefPrefix)
+	if !ok || name == "" || strings.Contains(name, "/") {
+		return "", fmt.Errorf("expected a reference to a component schema such as %sOrder, got: %q", openAPISchemaRefPrefix, ref)
+	}
+	name = strings.NewReplacer("~1", "/", "~0", "~").Replace(name)
+
+	if d.doc.Components == nil || d.doc.Components.Schemas[name] == nil || d.doc.Components.Schemas[name].Value == nil {
+		return "", fmt.Errorf("the document has no component schema %q", name)
+	}
+	return name, nil
+}
+
+// validator returns a validator for the component schema ref points to.
+func (d *openAPIDocument) validator(ref, direction string) (*openAPIValidator, error) {
+	name, err := d.schemaName(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	v := &openAPIValidator{
+		ref:     ref,
+		version: d.doc.OpenAPI,
+		schema:  d.doc.Components.Schemas[name].Value,
+		opts:    []openapi3.SchemaValidationOption{openapi3.MultiErrors(), openapi3.EnableFormatValidation()},
+	}
+	if d.doc.IsOpenAPI31OrLater() {
+		v.opts = append(v.opts, openapi3.EnableJSONSchema2020())
+	}
+	if direction == openAPIDirectionResponse {
+		v.opts = append(v.opts, openapi3.VisitAsResponse())
+	} else {
+		v.opts = append(v.opts, openapi3.VisitAsRequest())
+	}
+	return v, nil
+}
+
+// typeSchema returns the component schema ref points to as read for
+// schema_types, with its references expanded against the whole document.
+func (d *openAPIDocument) typeSchema(ref string) (*spec.Schema, error) {
+	name, err := d.schemaName(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := openAPITypeSchemaJSON(d.doc.Components.Schemas[name])
+	if err != nil {
+		return nil, err
+	}
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	s := new(spec.Schema)
+	if err := s.UnmarshalJSON(raw); err != nil {
+		return nil, err
+	}
+
+	if d.src.external {
+		err = spec.ExpandSchemaWithBasePath(s, nil, &spec.ExpandOptions{
+			RelativeBase: d.src.url.Path,
+			PathLoader: d.src.pathLoader(func(data []byte) ([]byte, error) {
+				doc, err := openAPITypeSchemaJSON(json.RawMessage(data))
+				if err != nil {
+					return nil, err
+				}
+				return json.Marshal(doc)
+			}),
+		})
+	} else {
+		var root interface{}
+		if root, err = openAPITypeSchemaJSON(d.doc); err == nil {
+			err = spec.ExpandSchema(s, root, nil)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// openAPITypeSchemaJSON converts v into generic JSON without the
+// discriminator objects of OpenAPI 3, which the Swagger 2.0 schemas of
+// go-openapi read as strings.
+func openAPITypeSchemaJSON(v interface{}) (interface{}, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var doc interface{}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+
+	var strip func(v interface{})
+	strip = func(v interface{}) {
+		switch v := v.(type) {
+		case map[string]interface{}:
+			if _, ok := v["discriminator"].(map[string]interface{}); ok {
+				delete(v, "discriminator")
+			}
+			for _, e := range v {
+				strip(e)
+			}
+		case []interface{}:
+			for _, e := range v {
+				strip(e)
+			}
+		}
+	}
+	strip(doc)
+	return doc, nil
+}
+
+// openAPIValidator validates items against a component schema of an OpenAPI
+// document, applying nullable, discriminator, readOnly and writeOnly.
+type openAPIValidator struct {
+	ref     string
+	version string
+	schema  *openapi3.Schema
+	opts    []openapi3.SchemaValidationOption
+}
+
+func (v *openAPIValidator) Validate(item interface{}) []specViolation {
+	if err := v.schema.VisitJSON(item, v.opts...); err != nil {
+		return openAPIViolations(err, v.ref)
+	}
+	return nil
+}
+
+func (v *openAPIValidator) Draft() string {
+	return "openapi-" + v.version
+}
@@ -0,0 +1,280 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	awstypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// PartiQLModel describes the arguments and attributes of the data sources
+// that render a converted item as PartiQL statements.
+type PartiQLModel struct {
+	PartiQLKeyAttributes    types.List           `tfsdk:"partiql_key_attributes"`
+	PartiQLInsert           types.String         `tfsdk:"partiql_insert"`
+	PartiQLInsertParameters jsontypes.Normalized `tfsdk:"partiql_insert_parameters"`
+	PartiQLUpdate           types.String         `tfsdk:"partiql_update"`
+	PartiQLUpdateParameters jsontypes.Normalized `tfsdk:"partiql_update_parameters"`
+}
+
+// partiQLAttributes returns the schema attributes of PartiQLModel.
+func partiQLAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"partiql_key_attributes": schema.ListAttribute{
+			MarkdownDescription: "Names of the key attributes of `table_name`, matched in the `WHERE` clause of `partiql_update`. Required for `partiql_update`.",
+			Optional:            true,
+			ElementType:         types.StringType,
+		},
+		"partiql_insert": schema.StringAttribute{
+			MarkdownDescription: "PartiQL `INSERT INTO \"table_name\" VALUE {...}` statement for the item, for `ExecuteStatement`. Values are PartiQL literals, except binary values which are `?` parameters.",
+			Computed:            true,
+		},
+		"partiql_insert_parameters": schema.StringAttribute{
+			MarkdownDescription: "DynamoDB JSON list of the `partiql_insert` parameters. Null when the statement has none.",
+			Computed:            true,
+			CustomType:          jsontypes.NormalizedType{},
+		},
+		"partiql_update": schema.StringAttribute{
+			MarkdownDescription: "PartiQL `UPDATE \"table_name\" SET ... WHERE ...` statement setting every non-key attribute of the item, with each value a `?` parameter.",
+			Computed:            true,
+		},
+		"partiql_update_parameters": schema.StringAttribute{
+			MarkdownDescription: "DynamoDB JSON list of the `partiql_update` parameters: the `SET` values, then the key values.",
+			Computed:            true,
+			CustomType:          jsontypes.NormalizedType{},
+		},
+	}
+}
+
+// setPartiQL renders the PartiQL attributes for the converted item. They are
+// null unless tableName is set, and the update also needs the key attributes.
+func (m *PartiQLModel) setPartiQL(ctx context.Context, tableName string, item map[string]awstypes.AttributeValue) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	m.PartiQLInsert = types.StringNull()
+	m.PartiQLInsertParameters = jsontypes.NewNormalizedNull()
+	m.PartiQLUpdate = types.StringNull()
+	m.PartiQLUpdateParameters = jsontypes.NewNormalizedNull()
+
+	var keys []string
+	diags.Append(m.PartiQLKeyAttributes.ElementsAs(ctx, &keys, false)...)
+
+	if diags.HasError() {
+		return diags
+	}
+
+	if tableName == "" {
+		if len(keys) > 0 {
+			diags.AddAttributeError(
+				path.Root("table_name"),
+				"Missing Table Name",
+				"partiql_key_attributes only applies to PartiQL statements, which require table_name to be set.",
+			)
+		}
+		return diags
+	}
+
+	statement, params, err := partiQLInsert(tableName, item)
+	if err == nil {
+		m.PartiQLInsert = types.StringValue(statement)
+		m.PartiQLInsertParameters, err = partiQLParameters(params)
+	}
+	if err != nil {
+		diags.AddError(
+			"PartiQL Serialization Failed",
+			fmt.Sprintf("The provider received an unexpected error while attempting to render the PartiQL INSERT statement.\n\nError: %s", err),
+		)
+		return diags
+	}
+
+	if len(keys) == 0 {
+		return diags
+	}
+
+	statement, params, err = partiQLUpdate(tableName, keys, item)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("partiql_key_attributes"),
+			"Invalid PartiQL Update",
+			fmt.Sprintf("The data source cannot render the PartiQL UPDATE statement.\n\nError: %s", err),
+		)
+		return diags
+	}
+	m.PartiQLUpdate = types.StringValue(statement)
+	if m.PartiQLUpdateParameters, err = partiQLParameters(params); err != nil {
+		diags.AddError(
+			"PartiQL Serialization Failed",
+			fmt.Sprintf("The provider received an unexpected error while attempting to render the PartiQL UPDATE parameters.\n\nError: %s", err),
+		)
+	}
+	return diags
+}
+
+// partiQLParameters renders statement parameters as a DynamoDB JSON list,
+// or null when there are none.
+func partiQLParameters(params []awstypes.AttributeValue) (jsontypes.Normalized, error) {
+	if len(params) == 0 {
+		return jsontypes.NewNormalizedNull(), nil
+	}
+	b, err := SerializeAttributeList(params)
+	if err != nil {
+		return jsontypes.NewNormalizedNull(), err
+	}
+	return jsontypes.NewNormalizedValue(string(b)), nil
+}
+
+// partiQLInsert renders an item as a PartiQL INSERT statement. PartiQL has no
+// binary literal, so binary values become parameters.
+func partiQLInsert(tableName string, item map[string]awstypes.AttributeValue) (string, []awstypes.AttributeValue, error) {
+	var sb strings.Builder
+	var params []awstypes.AttributeValue
+
+	sb.WriteString("INSERT INTO ")
+	sb.WriteString(partiQLIdentifier(tableName))
+	sb.WriteString(" VALUE ")
+	if err := writePartiQLMap(&sb, item, &params); err != nil {
+		return "", nil, err
+	}
+	return sb.String(), params, nil
+}
+
+// partiQLUpdate renders an item as a PartiQL UPDATE statement that sets each
+// non-key attribute and matches the key attributes. All values are parameters.
+func partiQLUpdate(tableName string, keys []string, item map[string]awstypes.AttributeValue) (string, []awstypes.AttributeValue, error) {
+	var sb strings.Builder
+	var params []awstypes.AttributeValue
+
+	sb.WriteString("UPDATE ")
+	sb.WriteString(partiQLIdentifier(tableName))
+	for _, k := range slices.Sorted(maps.Keys(item)) {
+		if slices.Contains(keys, k) {
+			continue
+		}
+		sb.WriteString(" SET ")
+		sb.WriteString(partiQLIdentifier(k))
+		sb.WriteString("=?")
+		params = append(params, item[k])
+	}
+	if len(params) == 0 {
+		return "", nil, fmt.Errorf("the item has no attributes besides the key attributes")
+	}
+
+	for i, k := range keys {
+		v, ok := item[k]
+		if !ok {
+			return "", nil, fmt.Errorf("key attribute %q is missing from the item", k)
+		}
+		if i == 0 {
+			sb.WriteString(" WHERE ")
+		} else {
+			sb.WriteString(" AND ")
+		}
+		sb.WriteString(partiQLIdentifier(k))
+		sb.WriteString("=?")
+		params = append(params, v)
+	}
+	return sb.String(), params, nil
+}
+
+// writePartiQLAttributeValue writes an attribute value as a PartiQL literal:
+// quoted strings, numeric literals, [...] lists, {...} maps and <<...>> sets.
+// Binary values are written as "?" and appended to params.
+func writePartiQLAttributeValue(sb *strings.Builder, v awstypes.AttributeValue, params *[]awstypes.AttributeValue) error {
+	switch uv := v.(type) {
+	case *awstypes.AttributeValueMemberB, *awstypes.AttributeValueMemberBS:
+		sb.WriteByte('?')
+		*params = append(*params, v)
+
+	case *awstypes.AttributeValueMemberBOOL:
+		fmt.Fprint(sb, uv.Value)
+
+	case *awstypes.AttributeValueMemberL:
+		sb.WriteByte('[')
+		for i, e := range uv.Value {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			if err := writePartiQLAttributeValue(sb, e, params); err != nil {
+				return err
+			}
+		}
+		sb.WriteByte(']')
+
+	case *awstypes.AttributeValueMemberM:
+		return writePartiQLMap(sb, uv.Value, params)
+
+	case *awstypes.AttributeValueMemberN:
+		if !jsonNumberPattern.MatchString(uv.Value) {
+			return fmt.Errorf("invalid number %q", uv.Value)
+		}
+		sb.WriteString(uv.Value)
+
+	case *awstypes.AttributeValueMemberNS:
+		sb.WriteString("<<")
+		for i, n := range uv.Value {
+			if !jsonNumberPattern.MatchString(n) {
+				return fmt.Errorf("invalid number %q", n)
+			}
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			sb.WriteString(n)
+		}
+		sb.WriteString(">>")
+
+	case *awstypes.AttributeValueMemberNULL:
+		sb.WriteString("NULL")
+
+	case *awstypes.AttributeValueMemberS:
+		sb.WriteString(partiQLString(uv.Value))
+
+	case *awstypes.AttributeValueMemberSS:
+		sb.WriteString("<<")
+		for i, s := range uv.Value {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			sb.WriteString(partiQLString(s))
+		}
+		sb.WriteString(">>")
+
+	default:
+		return fmt.Errorf("attempted to serialize unknown member type %T for union %T", uv, v)
+	}
+	return nil
+}
+
+func writePartiQLMap(sb *strings.Builder, m map[string]awstypes.AttributeValue, params *[]awstypes.AttributeValue) error {
+	sb.WriteByte('{')
+	for i, k := range slices.Sorted(maps.Keys(m)) {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(partiQLString(k))
+		sb.WriteString(": ")
+		if err := writePartiQLAttributeValue(sb, m[k], params); err != nil {
+			return fmt.Errorf("%s: %w", k, err)
+		}
+	}
+	sb.WriteByte('}')
+	return nil
+}
+
+// partiQLString quotes a PartiQL string literal.
+func partiQLString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// partiQLIdentifier quotes a PartiQL identifier such as a table or attribute
+// name, so reserved words and special characters are allowed.
+func partiQLIdentifier(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
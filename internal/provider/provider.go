@@ -2,14 +2,21 @@ package provider
 
 import (
 	"context"
-	"net/http"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 )
 
 // Ensure JSON2DynamoDBProvider satisfies various provider interfaces.
@@ -27,7 +34,50 @@ type JSON2DynamoDBProvider struct {
 
 // JSON2DynamoDBProviderModel describes the provider data model.
 type JSON2DynamoDBProviderModel struct {
-	// Endpoint types.String `tfsdk:"endpoint"`
+	AllowRemoteRefs types.Bool   `tfsdk:"allow_remote_refs"`
+	Region          types.String `tfsdk:"region"`
+	Endpoint        types.String `tfsdk:"endpoint"`
+	Profile         types.String `tfsdk:"profile"`
+	AssumeRole      types.Object `tfsdk:"assume_role"`
+	MaxRetries      types.Int64  `tfsdk:"max_retries"`
+	DefaultTags     types.Map    `tfsdk:"default_tags"`
+}
+
+// AssumeRoleModel describes the assume_role attribute.
+type AssumeRoleModel struct {
+	RoleARN     types.String `tfsdk:"role_arn"`
+	SessionName types.String `tfsdk:"session_name"`
+	ExternalID  types.String `tfsdk:"external_id"`
+	Duration    types.String `tfsdk:"duration"`
+}
+
+// providerData is handed to the data sources and resources of a configured
+// provider.
+type providerData struct {
+	// specs caches the spec documents the data sources read.
+	specs *specCache
+	// dynamodb is the DynamoDB client of the provider configuration.
+	dynamodb *dynamoDBClient
+}
+
+// configuredProviderData returns the data of the configured provider, or nil
+// while the provider is not configured yet.
+func configuredProviderData(data any) (*providerData, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	// Prevent panic if the provider has not been configured.
+	if data == nil {
+		return nil, diags
+	}
+	pd, ok := data.(*providerData)
+	if !ok {
+		diags.AddError(
+			"Unexpected Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", data),
+		)
+		return nil, diags
+	}
+	return pd, diags
 }
 
 func (p *JSON2DynamoDBProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -38,10 +88,53 @@ func (p *JSON2DynamoDBProvider) Metadata(ctx context.Context, req provider.Metad
 func (p *JSON2DynamoDBProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
-			// "endpoint": schema.StringAttribute{
-			// 	MarkdownDescription: "Example provider attribute",
-			// 	Optional:            true,
-			// },
+			"allow_remote_refs": schema.BoolAttribute{
+				MarkdownDescription: "Resolve `$ref`s to `http` and `https` URLs in specs read from `spec_file` or `spec_dir`. Disabled by default, so validating never reaches the network.",
+				Optional:            true,
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "AWS region of the DynamoDB tables. Defaults to the region of the AWS configuration, e.g. the `AWS_REGION` environment variable.",
+				Optional:            true,
+			},
+			"endpoint": schema.StringAttribute{
+				MarkdownDescription: "URL of the DynamoDB endpoint, e.g. `http://localhost:8000` for DynamoDB Local. Defaults to the endpoint of the AWS configuration, e.g. the `AWS_ENDPOINT_URL_DYNAMODB` environment variable.",
+				Optional:            true,
+			},
+			"profile": schema.StringAttribute{
+				MarkdownDescription: "Name of the profile in the shared AWS config and credentials files to use. Defaults to the `AWS_PROFILE` environment variable.",
+				Optional:            true,
+			},
+			"assume_role": schema.SingleNestedAttribute{
+				MarkdownDescription: "Role to assume with the credentials of the AWS configuration",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"role_arn": schema.StringAttribute{
+						MarkdownDescription: "ARN of the role",
+						Required:            true,
+					},
+					"session_name": schema.StringAttribute{
+						MarkdownDescription: "Name of the role session",
+						Optional:            true,
+					},
+					"external_id": schema.StringAttribute{
+						MarkdownDescription: "External ID the trust policy of the role requires",
+						Optional:            true,
+					},
+					"duration": schema.StringAttribute{
+						MarkdownDescription: "Duration of the role session, e.g. `1h`. Defaults to 15 minutes.",
+						Optional:            true,
+					},
+				},
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "Number of times a failed or throttled DynamoDB request is retried. Defaults to the retry mode of the AWS configuration, which retries twice.",
+				Optional:            true,
+			},
+			"default_tags": schema.MapAttribute{
+				MarkdownDescription: "**Reserved**, and has no effect: tags the provider will apply to the taggable AWS resources it manages. It only manages DynamoDB items so far, which do not support tags, so setting `default_tags` is reported as a warning.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
 		},
 	}
 }
@@ -55,18 +148,97 @@ func (p *JSON2DynamoDBProvider) Configure(ctx context.Context, req provider.Conf
 		return
 	}
 
-	// Configuration values are now available.
-	// if data.Endpoint.IsNull() { /* ... */ }
+	cfg := awsConfig{
+		Region:     data.Region.ValueString(),
+		Endpoint:   data.Endpoint.ValueString(),
+		Profile:    data.Profile.ValueString(),
+		MaxRetries: -1,
+	}
+
+	// assume_role is decoded only when known, as a whole unknown object
+	// cannot be decoded into its model.
+	var role *AssumeRoleModel
+	if !data.AssumeRole.IsNull() && !data.AssumeRole.IsUnknown() {
+		resp.Diagnostics.Append(data.AssumeRole.As(ctx, &role, basetypes.ObjectAsOptions{})...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 
-	// Example client configuration for data sources and resources
-	client := http.DefaultClient
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	unknown := map[string]bool{
+		"region":      data.Region.IsUnknown(),
+		"endpoint":    data.Endpoint.IsUnknown(),
+		"profile":     data.Profile.IsUnknown(),
+		"assume_role": data.AssumeRole.IsUnknown(),
+		"max_retries": data.MaxRetries.IsUnknown(),
+	}
+	if role != nil {
+		unknown["assume_role.role_arn"] = role.RoleARN.IsUnknown()
+		unknown["assume_role.session_name"] = role.SessionName.IsUnknown()
+		unknown["assume_role.external_id"] = role.ExternalID.IsUnknown()
+		unknown["assume_role.duration"] = role.Duration.IsUnknown()
+	}
+	for _, name := range slices.Sorted(maps.Keys(unknown)) {
+		if unknown[name] {
+			cfg.Unknown = append(cfg.Unknown, name)
+		}
+	}
+
+	if !data.MaxRetries.IsNull() && !data.MaxRetries.IsUnknown() {
+		if data.MaxRetries.ValueInt64() < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_retries"),
+				"Invalid Max Retries",
+				fmt.Sprintf("Expected max_retries to be at least 0, got: %d.", data.MaxRetries.ValueInt64()),
+			)
+		}
+		cfg.MaxRetries = int(data.MaxRetries.ValueInt64())
+	}
+
+	if role != nil {
+		cfg.AssumeRole = &assumeRoleConfig{
+			RoleARN:     role.RoleARN.ValueString(),
+			SessionName: role.SessionName.ValueString(),
+			ExternalID:  role.ExternalID.ValueString(),
+		}
+		if role.Duration.ValueString() != "" {
+			duration, err := time.ParseDuration(role.Duration.ValueString())
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("assume_role").AtName("duration"),
+					"Invalid Assume Role Duration",
+					fmt.Sprintf("Expected a duration such as \"1h\" or \"30m\", got: %q.", role.Duration.ValueString()),
+				)
+			}
+			cfg.AssumeRole.Duration = duration
+		}
+	}
+
+	if !data.DefaultTags.IsNull() {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("default_tags"),
+			"Reserved Argument",
+			"default_tags is reserved for the taggable AWS resources the provider may manage in the future, and has no effect: the provider only manages DynamoDB items, which do not support tags.",
+		)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pd := &providerData{
+		specs:    newSpecCache(data.AllowRemoteRefs.ValueBool()),
+		dynamodb: newDynamoDBClient(cfg),
+	}
+	resp.DataSourceData = pd
+	resp.ResourceData = pd
 }
 
 func (p *JSON2DynamoDBProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
-		// NewExampleResource,
+		NewJSON2DynamoDBTableItemResource,
+		NewJSON2DynamoDBTableItemsResource,
 	}
 }
 
@@ -79,12 +251,22 @@ func (p *JSON2DynamoDBProvider) EphemeralResources(ctx context.Context) []func()
 func (p *JSON2DynamoDBProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewJSON2DynamoDBDataSource,
+		NewJSON2DynamoDBDecodeDataSource,
+		NewJSON2DynamoDBItemDataSource,
+		NewJSON2DynamoDBItemsDataSource,
+		NewJSON2DynamoDBQueryDataSource,
+		NewJSON2DynamoDBS3ImportDataSource,
+		NewJSON2DynamoDBScanDataSource,
+		NewJSON2DynamoDBSchemaInferDataSource,
+		NewJSON2DynamoDBTableSchemaDataSource,
+		NewJSON2DynamoDBUpdateExpressionDataSource,
 	}
 }
 
 func (p *JSON2DynamoDBProvider) Functions(ctx context.Context) []func() function.Function {
 	return []func() function.Function{
-		// NewExampleFunction,
+		NewEncodeFunction,
+		NewDecodeFunction,
 	}
 }
 
@@ -1,11 +1,17 @@
 package provider
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 // testAccProtoV6ProviderFactoriesWithEcho includes the echo provider alongside the scaffolding provider.
@@ -22,3 +28,146 @@ func testAccPreCheck(t *testing.T) {
 	// about the appropriate environment variables being set are common to see in a pre-check
 	// function.
 }
+
+func testProviderConfig_aws(endpoint, provider string) string {
+	return fmt.Sprintf(`
+provider "json2dynamodb" {
+  endpoint = %q
+%s
+}
+
+resource "json2dynamodb_table_item" "test" {
+  table_name = "orders"
+  hash_key   = "pk"
+  json       = jsonencode({ pk = "a" })
+}
+`, endpoint, provider)
+}
+
+func TestProvider_aws(t *testing.T) {
+	fake := newFakeDynamoDB(t)
+	fake.createTable("orders", "pk", "")
+
+	// Requests only reach the fake through the endpoint of the provider.
+	t.Setenv("AWS_ENDPOINT_URL_DYNAMODB", "http://127.0.0.1:1")
+	// The region of the environment takes precedence over the profile.
+	t.Setenv("AWS_REGION", "")
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "config"), []byte("[profile other]\nregion = ap-south-1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "credentials"), []byte("[other]\naws_access_key_id = PROFILE\naws_secret_access_key = secret\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("AWS_CONFIG_FILE", filepath.Join(dir, "config"))
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(dir, "credentials"))
+
+	checkCredential := func(want string) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			if got := fake.lastCredential(); got != want {
+				return fmt.Errorf("expected requests signed for %s, got %s", want, got)
+			}
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		Steps: []resource.TestStep{
+			{
+				Config: testProviderConfig_aws(fake.URL, `
+  region = terraform_data.region.output
+}
+
+resource "terraform_data" "region" {
+  input = "eu-west-1"
+}
+
+data "json2dynamodb_item" "test" {
+  table_name = "orders"
+  key        = jsonencode({ pk = "a" })
+`),
+				ExpectError: regexp.MustCompile(`as the value of region is not\s+known yet`),
+			},
+			{
+				Config: testProviderConfig_aws(fake.URL, `
+  assume_role = terraform_data.role.output
+}
+
+resource "terraform_data" "role" {
+  input = {
+    role_arn     = "arn:aws:iam::123456789012:role/writer"
+    session_name = "terraform"
+    external_id  = "orders"
+    duration     = "30m"
+  }
+}
+
+data "json2dynamodb_item" "test" {
+  table_name = "orders"
+  key        = jsonencode({ pk = "a" })
+`),
+				ExpectError: regexp.MustCompile(`as the value of assume_role is\s+not known yet`),
+			},
+			{
+				Config: testProviderConfig_aws(fake.URL, `
+  assume_role = {
+    role_arn = "arn:aws:iam::123456789012:role/writer"
+    duration = "half an hour"
+  }
+`),
+				ExpectError: regexp.MustCompile(`Expected a duration such as "1h" or "30m", got: "half an hour"`),
+			},
+			{
+				Config:      testProviderConfig_aws(fake.URL, `max_retries = -1`),
+				ExpectError: regexp.MustCompile(`Expected max_retries to be at least 0, got: -1`),
+			},
+			{
+				Config: testProviderConfig_aws(fake.URL, `
+  region       = "eu-west-1"
+  max_retries  = 1
+  default_tags = { team = "orders" }
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					checkCredential("test/eu-west-1"),
+					func(*terraform.State) error {
+						return fake.checkItems("orders", map[string]string{
+							`{"pk":{"S":"a"}}`: `{"pk":{"S":"a"}}`,
+						})()
+					},
+				),
+			},
+			{
+				Config: testProviderConfig_aws(fake.URL, `
+  profile = "other"
+`),
+				Check: checkCredential("PROFILE/ap-south-1"),
+			},
+			{
+				Config: testProviderConfig_aws(fake.URL, `
+  region = "us-west-2"
+  assume_role = {
+    role_arn     = "arn:aws:iam::123456789012:role/writer"
+    session_name = "terraform"
+    external_id  = "orders"
+    duration     = "30m"
+  }
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					checkCredential("ASSUMED/us-west-2"),
+					func(*terraform.State) error {
+						fake.mu.Lock()
+						defer fake.mu.Unlock()
+						role := fake.assumedRoles[len(fake.assumedRoles)-1]
+						if role.Get("RoleArn") != "arn:aws:iam::123456789012:role/writer" || role.Get("RoleSessionName") != "terraform" || role.Get("ExternalId") != "orders" || role.Get("DurationSeconds") != "1800" {
+							return fmt.Errorf("unexpected AssumeRole request: %v", role)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
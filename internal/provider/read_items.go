@@ -0,0 +1,217 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awstypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ReadItemsModel describes the arguments and attributes shared by the data
+// sources that read sets of items with Query or Scan.
+type ReadItemsModel struct {
+	TableName                 types.String         `tfsdk:"table_name"`
+	IndexName                 types.String         `tfsdk:"index_name"`
+	FilterExpression          types.String         `tfsdk:"filter_expression"`
+	ProjectionExpression      types.String         `tfsdk:"projection_expression"`
+	ExpressionAttributeNames  types.Map            `tfsdk:"expression_attribute_names"`
+	ExpressionAttributeValues jsontypes.Normalized `tfsdk:"expression_attribute_values"`
+	TypeHints                 types.Map            `tfsdk:"type_hints"`
+	ConsistentRead            types.Bool           `tfsdk:"consistent_read"`
+	Limit                     types.Int64          `tfsdk:"limit"`
+	SetFormat                 types.String         `tfsdk:"set_format"`
+	BinaryFormat              types.String         `tfsdk:"binary_format"`
+	Endpoint                  types.String         `tfsdk:"endpoint"`
+	Items                     types.List           `tfsdk:"items"`
+	Id                        types.String         `tfsdk:"id"`
+}
+
+// readItemsAttributes returns the schema attributes of ReadItemsModel.
+func readItemsAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"table_name": schema.StringAttribute{
+			MarkdownDescription: "Name of the table",
+			Required:            true,
+		},
+		"index_name": schema.StringAttribute{
+			MarkdownDescription: "Name of a secondary index of the table to read instead of the table",
+			Optional:            true,
+		},
+		"filter_expression": schema.StringAttribute{
+			MarkdownDescription: "Condition the items must meet to be returned, e.g. `enabled = :enabled`. It is applied after the items are read, so it does not reduce the capacity consumed.",
+			Optional:            true,
+		},
+		"projection_expression": schema.StringAttribute{
+			MarkdownDescription: "Attributes to read, e.g. `pk, settings.theme`. Defaults to all attributes.",
+			Optional:            true,
+		},
+		"expression_attribute_names": schema.MapAttribute{
+			MarkdownDescription: "Substitutions for attribute names in the expressions, e.g. `{ \"#name\" = \"name\" }` for reserved words",
+			Optional:            true,
+			ElementType:         types.StringType,
+		},
+		"expression_attribute_values": schema.StringAttribute{
+			MarkdownDescription: "Values of the placeholders in the expressions as a plain JSON object, e.g. `{\":tenant\":\"a\"}`. The values are converted like the items of the `json2dynamodb` data source.",
+			Optional:            true,
+			CustomType:          jsontypes.NormalizedType{},
+		},
+		"type_hints": schema.MapAttribute{
+			MarkdownDescription: "Map of JSON Pointer patterns to the DynamoDB type the matching values of `expression_attribute_values` are encoded as, e.g. `{ \"/:id\" = \"B\" }` for a base64 binary value",
+			Optional:            true,
+			ElementType:         types.StringType,
+		},
+		"consistent_read": schema.BoolAttribute{
+			MarkdownDescription: "Read the items with strongly consistent reads. Not supported on global secondary indexes. Defaults to `false`.",
+			Optional:            true,
+		},
+		"limit": schema.Int64Attribute{
+			MarkdownDescription: "Maximum number of items to return. Defaults to all the items.",
+			Optional:            true,
+		},
+		"set_format": schema.StringAttribute{
+			MarkdownDescription: "How `SS`, `NS` and `BS` sets are rendered: `array` keeps the stored order, `sorted` sorts the members. Defaults to `array`.",
+			Optional:            true,
+		},
+		"binary_format": schema.StringAttribute{
+			MarkdownDescription: "How `B` and `BS` values are rendered: `base64` as standard base64 strings, `utf8` as UTF-8 strings. Defaults to `base64`.",
+			Optional:            true,
+		},
+		"endpoint": schema.StringAttribute{
+			MarkdownDescription: "URL of the DynamoDB endpoint, e.g. `http://localhost:8000` for DynamoDB Local. Defaults to the `endpoint` of the provider.",
+			Optional:            true,
+		},
+		"items": schema.ListAttribute{
+			MarkdownDescription: "The items as plain JSON, in the order they were read",
+			Computed:            true,
+			ElementType:         jsontypes.NormalizedType{},
+		},
+		"id": schema.StringAttribute{
+			MarkdownDescription: "The ID of this data source",
+			Computed:            true,
+		},
+	}
+}
+
+// readItemsInput holds the request parameters Query and Scan share.
+type readItemsInput struct {
+	TableName                 *string
+	IndexName                 *string
+	FilterExpression          *string
+	ProjectionExpression      *string
+	ExpressionAttributeNames  map[string]string
+	ExpressionAttributeValues map[string]awstypes.AttributeValue
+	ConsistentRead            *bool
+}
+
+// itemPage reads one page of items from startKey, at most limit items when
+// it is set, and returns the key to read the next page from.
+type itemPage func(ctx context.Context, startKey map[string]awstypes.AttributeValue, limit *int32) ([]map[string]awstypes.AttributeValue, map[string]awstypes.AttributeValue, error)
+
+// input validates the shared arguments and returns the request parameters
+// they set.
+func (m *ReadItemsModel) input(ctx context.Context) (*readItemsInput, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if !m.Limit.IsNull() && m.Limit.ValueInt64() < 1 {
+		diags.AddAttributeError(
+			path.Root("limit"),
+			"Invalid Limit",
+			fmt.Sprintf("Expected limit to be at least 1, got: %d.", m.Limit.ValueInt64()),
+		)
+	}
+
+	var typeHints map[string]string
+	input := &readItemsInput{
+		TableName:      aws.String(m.TableName.ValueString()),
+		ConsistentRead: aws.Bool(m.ConsistentRead.ValueBool()),
+	}
+	diags.Append(m.TypeHints.ElementsAs(ctx, &typeHints, false)...)
+	diags.Append(m.ExpressionAttributeNames.ElementsAs(ctx, &input.ExpressionAttributeNames, false)...)
+
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	if m.IndexName.ValueString() != "" {
+		input.IndexName = aws.String(m.IndexName.ValueString())
+	}
+	if m.FilterExpression.ValueString() != "" {
+		input.FilterExpression = aws.String(m.FilterExpression.ValueString())
+	}
+	if m.ProjectionExpression.ValueString() != "" {
+		input.ProjectionExpression = aws.String(m.ProjectionExpression.ValueString())
+	}
+	if len(input.ExpressionAttributeNames) == 0 {
+		input.ExpressionAttributeNames = nil
+	}
+
+	if !m.ExpressionAttributeValues.IsNull() {
+		values, valueDiags := encodeItemJSON(m.ExpressionAttributeValues.ValueString(), encodeOptions{TypeHints: typeHints})
+		diags.Append(attributeDiagnostics(path.Root("expression_attribute_values"), valueDiags)...)
+		input.ExpressionAttributeValues = values
+	}
+	return input, diags
+}
+
+// readItems reads pages of items until the last page or limit, and sets
+// items to them. operation names the API call in diagnostics.
+func (m *ReadItemsModel) readItems(ctx context.Context, operation string, readPage itemPage) diag.Diagnostics {
+	opts := decodeOptions{
+		SetFormat:    m.SetFormat.ValueString(),
+		BinaryFormat: m.BinaryFormat.ValueString(),
+	}
+	diags := opts.setDefaults()
+
+	if diags.HasError() {
+		return diags
+	}
+
+	items := []jsontypes.Normalized{}
+	var startKey map[string]awstypes.AttributeValue
+	for {
+		// Ask for no more items than are still missing. DynamoDB counts the
+		// items it reads before filter_expression, so a page may hold fewer.
+		var limit *int32
+		if !m.Limit.IsNull() {
+			limit = aws.Int32(int32(min(m.Limit.ValueInt64()-int64(len(items)), 1<<31-1)))
+		}
+
+		page, lastKey, err := readPage(ctx, startKey, limit)
+		if err != nil {
+			diags.AddError(
+				fmt.Sprintf("DynamoDB %s Failed", operation),
+				fmt.Sprintf("The provider received an unexpected error while attempting to read the items.\n\nError: %s", err),
+			)
+			return diags
+		}
+
+		for _, item := range page {
+			result, err := itemJSON(item, opts)
+			if err != nil {
+				diags.AddError(
+					"DynamoDB Item Decoding Failed",
+					fmt.Sprintf("The provider received an unexpected error while attempting to transform item %d into JSON.\n\nError: %s", len(items), err),
+				)
+				return diags
+			}
+			items = append(items, jsontypes.NewNormalizedValue(result))
+		}
+
+		if len(lastKey) == 0 || (!m.Limit.IsNull() && int64(len(items)) >= m.Limit.ValueInt64()) {
+			break
+		}
+		startKey = lastKey
+	}
+
+	var listDiags diag.Diagnostics
+	m.Items, listDiags = types.ListValueFrom(ctx, jsontypes.NormalizedType{}, items)
+	diags.Append(listDiags...)
+	m.Id = types.StringValue(m.TableName.ValueString())
+	return diags
+}
@@ -0,0 +1,468 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	awstypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &JSON2DynamoDBTableItemResource{}
+var _ resource.ResourceWithConfigure = &JSON2DynamoDBTableItemResource{}
+var _ resource.ResourceWithModifyPlan = &JSON2DynamoDBTableItemResource{}
+var _ resource.ResourceWithImportState = &JSON2DynamoDBTableItemResource{}
+
+func NewJSON2DynamoDBTableItemResource() resource.Resource {
+	return &JSON2DynamoDBTableItemResource{}
+}
+
+// JSON2DynamoDBTableItemResource defines the resource implementation.
+type JSON2DynamoDBTableItemResource struct {
+	client *dynamoDBClient
+}
+
+// JSON2DynamoDBTableItemResourceModel describes the resource data model.
+type JSON2DynamoDBTableItemResourceModel struct {
+	TableName types.String         `tfsdk:"table_name"`
+	HashKey   types.String         `tfsdk:"hash_key"`
+	RangeKey  types.String         `tfsdk:"range_key"`
+	JSON      jsontypes.Normalized `tfsdk:"json"`
+	TypeHints types.Map            `tfsdk:"type_hints"`
+	Endpoint  types.String         `tfsdk:"endpoint"`
+	Id        types.String         `tfsdk:"id"`
+}
+
+func (r *JSON2DynamoDBTableItemResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_table_item"
+}
+
+func (r *JSON2DynamoDBTableItemResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages one item of a DynamoDB table, given as plain JSON. The item is written with PutItem and read back with GetItem, and changes made outside Terraform show up as differences in `json`. Changing the key of the item replaces it. Items are imported by their `id`, prefixed by the endpoint and `|` for tables reachable only through `endpoint`, e.g. `http://localhost:8000|orders/{\"pk\":\"a\",\"sk\":1}`.",
+
+		Attributes: map[string]schema.Attribute{
+			"table_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the table",
+				Required:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"hash_key": schema.StringAttribute{
+				MarkdownDescription: "Name of the hash (partition) key attribute of the table",
+				Required:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"range_key": schema.StringAttribute{
+				MarkdownDescription: "Name of the range (sort) key attribute of the table, if it has one",
+				Optional:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"json": schema.StringAttribute{
+				MarkdownDescription: "The item as a JSON object, including its key attributes. Sets are read back sorted and binary values as base64.",
+				Required:            true,
+				CustomType:          jsontypes.NormalizedType{},
+			},
+			"type_hints": schema.MapAttribute{
+				MarkdownDescription: "Map of JSON Pointer patterns to the DynamoDB type the matching values are encoded as, as for the `json2dynamodb` data source",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"endpoint": schema.StringAttribute{
+				MarkdownDescription: "URL of the DynamoDB endpoint, e.g. `http://localhost:8000` for DynamoDB Local. Defaults to the `endpoint` of the provider.",
+				Optional:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The table name and the key of the item in plain JSON, e.g. `orders/{\"pk\":\"a\",\"sk\":1}`",
+				Computed:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+		},
+	}
+}
+
+// encode converts the item into DynamoDB attribute values and returns its
+// key.
+func (m *JSON2DynamoDBTableItemResourceModel) encode(ctx context.Context) (map[string]awstypes.AttributeValue, map[string]awstypes.AttributeValue, diag.Diagnostics) {
+	var typeHints map[string]string
+	diags := m.TypeHints.ElementsAs(ctx, &typeHints, false)
+
+	if diags.HasError() {
+		return nil, nil, diags
+	}
+
+	item, itemDiags := encodeItemJSON(m.JSON.ValueString(), encodeOptions{TypeHints: typeHints})
+	diags.Append(itemDiags...)
+
+	if diags.HasError() {
+		return nil, nil, diags
+	}
+
+	key, err := tableItemKey(item, m.HashKey.ValueString(), m.RangeKey.ValueString())
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("json"),
+			"Invalid Key Attribute",
+			fmt.Sprintf("The item cannot be written to the table.\n\nError: %s.", err),
+		)
+		return nil, nil, diags
+	}
+	return item, key, diags
+}
+
+func (r *JSON2DynamoDBTableItemResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	pd, diags := configuredProviderData(req.ProviderData)
+	resp.Diagnostics.Append(diags...)
+
+	if pd != nil {
+		r.client = pd.dynamodb
+	}
+}
+
+func (r *JSON2DynamoDBTableItemResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan JSON2DynamoDBTableItemResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() || plan.JSON.IsUnknown() || plan.TypeHints.IsUnknown() || plan.TableName.IsUnknown() {
+		return
+	}
+
+	// Report invalid items at plan time rather than when they are written.
+	_, key, diags := plan.encode(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var state JSON2DynamoDBTableItemResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := tableItemID(plan.TableName.ValueString(), key)
+	if err == nil && id != state.Id.ValueString() {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("json"))
+	}
+}
+
+func (r *JSON2DynamoDBTableItemResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data JSON2DynamoDBTableItemResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	item, key, diags := data.encode(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := r.client.get(ctx, data.Endpoint.ValueString())
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := tableItemID(data.TableName.ValueString(), key)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"DynamoDB Item Key Failed",
+			fmt.Sprintf("The provider received an unexpected error while attempting to render the key of the item.\n\nError: %s", err),
+		)
+		return
+	}
+
+	// Do not overwrite an item Terraform does not manage yet.
+	_, err = client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:                aws.String(data.TableName.ValueString()),
+		Item:                     item,
+		ConditionExpression:      aws.String("attribute_not_exists(#hash_key)"),
+		ExpressionAttributeNames: map[string]string{"#hash_key": data.HashKey.ValueString()},
+	})
+	var exists *awstypes.ConditionalCheckFailedException
+	if errors.As(err, &exists) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("json"),
+			"DynamoDB Item Already Exists",
+			fmt.Sprintf("The table already has an item with the key of this item. Import it with the ID %s, or delete it first.", id),
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"DynamoDB PutItem Failed",
+			fmt.Sprintf("The provider received an unexpected error while attempting to write the item.\n\nError: %s", err),
+		)
+		return
+	}
+
+	data.Id = types.StringValue(id)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *JSON2DynamoDBTableItemResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data JSON2DynamoDBTableItemResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, key, diags := data.encode(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := r.client.get(ctx, data.Endpoint.ValueString())
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	out, err := client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName:      aws.String(data.TableName.ValueString()),
+		Key:            key,
+		ConsistentRead: aws.Bool(true),
+	})
+	var notFound *awstypes.ResourceNotFoundException
+	if errors.As(err, &notFound) || (err == nil && out.Item == nil) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"DynamoDB GetItem Failed",
+			fmt.Sprintf("The provider received an unexpected error while attempting to read the item.\n\nError: %s", err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(data.refresh(ctx, out.Item)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// refresh replaces json with the item as stored, unless it is the item json
+// already describes, so formatting and the order of set members does not
+// show up as a difference.
+func (m *JSON2DynamoDBTableItemResourceModel) refresh(ctx context.Context, stored map[string]awstypes.AttributeValue) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	actual, err := plainItem(stored)
+	if err != nil {
+		diags.AddError(
+			"DynamoDB Item Decoding Failed",
+			fmt.Sprintf("The provider received an unexpected error while attempting to transform the stored item into JSON.\n\nError: %s", err),
+		)
+		return diags
+	}
+
+	item, _, itemDiags := m.encode(ctx)
+	if !itemDiags.HasError() {
+		if expected, err := plainItem(item); err == nil && plainJSONEqual(expected, actual) {
+			return diags
+		}
+	}
+
+	result, err := plainJSON(stored)
+	if err != nil {
+		diags.AddError(
+			"DynamoDB Item Decoding Failed",
+			fmt.Sprintf("The provider received an unexpected error while attempting to transform the stored item into JSON.\n\nError: %s", err),
+		)
+		return diags
+	}
+	m.JSON = jsontypes.NewNormalizedValue(result)
+	return diags
+}
+
+func (r *JSON2DynamoDBTableItemResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data JSON2DynamoDBTableItemResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	item, _, diags := data.encode(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := r.client.get(ctx, data.Endpoint.ValueString())
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// ModifyPlan replaces the item when its key changes, so this overwrites
+	// the same item.
+	_, err := client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(data.TableName.ValueString()),
+		Item:      item,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"DynamoDB PutItem Failed",
+			fmt.Sprintf("The provider received an unexpected error while attempting to write the item.\n\nError: %s", err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *JSON2DynamoDBTableItemResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data JSON2DynamoDBTableItemResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, key, diags := data.encode(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := r.client.get(ctx, data.Endpoint.ValueString())
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(data.TableName.ValueString()),
+		Key:       key,
+	})
+	var notFound *awstypes.ResourceNotFoundException
+	if err != nil && !errors.As(err, &notFound) {
+		resp.Diagnostics.AddError(
+			"DynamoDB DeleteItem Failed",
+			fmt.Sprintf("The provider received an unexpected error while attempting to delete the item.\n\nError: %s", err),
+		)
+	}
+}
+
+// ImportState imports an item by its ID, the table name and the key of the
+// item in plain JSON, optionally prefixed by the endpoint of the table. The
+// key attributes are read from the table.
+func (r *JSON2DynamoDBTableItemResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	endpoint, id, err := splitTableItemImportID(req.ID)
+	var tableName string
+	var key map[string]awstypes.AttributeValue
+	if err == nil {
+		tableName, key, err = parseTableItemID(id)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("The provider could not parse the import ID.\n\nError: %s", err),
+		)
+		return
+	}
+
+	data := JSON2DynamoDBTableItemResourceModel{
+		TableName: types.StringValue(tableName),
+		RangeKey:  types.StringNull(),
+		TypeHints: types.MapNull(types.StringType),
+		Endpoint:  types.StringNull(),
+	}
+	if endpoint != "" {
+		data.Endpoint = types.StringValue(endpoint)
+	}
+
+	client, diags := r.client.get(ctx, data.Endpoint.ValueString())
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	out, err := client.DescribeTable(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(tableName)})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"DynamoDB DescribeTable Failed",
+			fmt.Sprintf("The provider received an unexpected error while attempting to read the key schema of the table.\n\nError: %s", err),
+		)
+		return
+	}
+	for _, k := range out.Table.KeySchema {
+		switch k.KeyType {
+		case awstypes.KeyTypeHash:
+			data.HashKey = types.StringValue(aws.ToString(k.AttributeName))
+		case awstypes.KeyTypeRange:
+			data.RangeKey = types.StringValue(aws.ToString(k.AttributeName))
+		}
+	}
+
+	if _, err := tableItemKey(key, data.HashKey.ValueString(), data.RangeKey.ValueString()); err != nil || len(key) != len(out.Table.KeySchema) {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("The key in the import ID must have exactly the key attributes of table %q: %s.", tableName, keyAttributeNames(out.Table.KeySchema)),
+		)
+		return
+	}
+
+	// Read replaces json with the whole item.
+	keyJSON, err := plainJSON(key)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("The provider received an unexpected error while attempting to render the key.\n\nError: %s", err),
+		)
+		return
+	}
+	data.JSON = jsontypes.NewNormalizedValue(keyJSON)
+	data.Id = types.StringValue(id)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// keyAttributeNames lists the key attributes of a table, hash key first.
+func keyAttributeNames(keySchema []awstypes.KeySchemaElement) string {
+	var names string
+	for i, k := range keySchema {
+		if i > 0 {
+			names += " and "
+		}
+		names += fmt.Sprintf("%q", aws.ToString(k.AttributeName))
+	}
+	return names
+}
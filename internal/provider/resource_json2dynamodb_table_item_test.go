@@ -0,0 +1,165 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func testTableItemResourceConfig(endpoint, item string) string {
+	return fmt.Sprintf(`
+resource "json2dynamodb_table_item" "test" {
+  table_name = "orders"
+  hash_key   = "pk"
+  range_key  = "sk"
+  endpoint   = %q
+
+  json       = jsonencode(%s)
+  type_hints = { "/tags" = "SS" }
+}
+`, endpoint, item)
+}
+
+func TestTableItemResource_basic(t *testing.T) {
+	fake := newFakeDynamoDB(t)
+	fake.createTable("orders", "pk", "sk")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		CheckDestroy: func(*terraform.State) error {
+			return fake.checkItems("orders", nil)()
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testTableItemResourceConfig(fake.URL, `{ pk = "a", sk = 1, total = 1.5, tags = ["y", "x"] }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("json2dynamodb_table_item.test", "id", `orders/{"pk":"a","sk":1}`),
+					func(*terraform.State) error {
+						return fake.checkItems("orders", map[string]string{
+							`{"pk":{"S":"a"},"sk":{"N":"1"}}`: `{"pk":{"S":"a"},"sk":{"N":"1"},"tags":{"SS":["y","x"]},"total":{"N":"1.5"}}`,
+						})()
+					},
+				),
+			},
+			// Changes made outside Terraform show up as a difference in json.
+			{
+				PreConfig: func() {
+					fake.putItem(t, "orders", `{"pk":{"S":"a"},"sk":{"N":"1"},"tags":{"SS":["x","y"]},"total":{"N":"2"}}`)
+				},
+				Config:             testTableItemResourceConfig(fake.URL, `{ pk = "a", sk = 1, total = 1.5, tags = ["y", "x"] }`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// The order of set members and the formatting of numbers are not.
+			{
+				PreConfig: func() {
+					fake.putItem(t, "orders", `{"pk":{"S":"a"},"sk":{"N":"1"},"tags":{"SS":["x","y"]},"total":{"N":"1.50"}}`)
+				},
+				Config:   testTableItemResourceConfig(fake.URL, `{ pk = "a", sk = 1, total = 1.5, tags = ["y", "x"] }`),
+				PlanOnly: true,
+			},
+			{
+				Config: testTableItemResourceConfig(fake.URL, `{ pk = "a", sk = 1, total = 3 }`),
+				Check: func(*terraform.State) error {
+					return fake.checkItems("orders", map[string]string{
+						`{"pk":{"S":"a"},"sk":{"N":"1"}}`: `{"pk":{"S":"a"},"sk":{"N":"1"},"total":{"N":"3"}}`,
+					})()
+				},
+			},
+			// Changing the key replaces the item.
+			{
+				Config: testTableItemResourceConfig(fake.URL, `{ pk = "a", sk = 2, total = 3 }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("json2dynamodb_table_item.test", "id", `orders/{"pk":"a","sk":2}`),
+					func(*terraform.State) error {
+						return fake.checkItems("orders", map[string]string{
+							`{"pk":{"S":"a"},"sk":{"N":"2"}}`: `{"pk":{"S":"a"},"sk":{"N":"2"},"total":{"N":"3"}}`,
+						})()
+					},
+				),
+			},
+			{
+				ResourceName:            "json2dynamodb_table_item.test",
+				ImportState:             true,
+				ImportStateId:           `orders/{"pk":"a","sk":2}`,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"endpoint", "type_hints"},
+			},
+		},
+	})
+}
+
+func TestTableItemResource_invalid(t *testing.T) {
+	fake := newFakeDynamoDB(t)
+	fake.createTable("orders", "pk", "sk")
+	fake.putItem(t, "orders", `{"pk":{"S":"a"},"sk":{"N":"1"}}`)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		Steps: []resource.TestStep{
+			{
+				Config:      testTableItemResourceConfig(fake.URL, `{ pk = "a", sk = 1 }`),
+				ExpectError: regexp.MustCompile(`Import it with the\s+ID orders/\{"pk":"a","sk":1\}`),
+			},
+			{
+				Config:      testTableItemResourceConfig(fake.URL, `{ pk = "a" }`),
+				ExpectError: regexp.MustCompile(`the item has no key attribute "sk"`),
+			},
+			{
+				Config:      testTableItemResourceConfig(fake.URL, `{ pk = "a", sk = true }`),
+				ExpectError: regexp.MustCompile(`key attribute "sk" must be a string, number or binary value, got BOOL`),
+			},
+			{
+				ResourceName:  "json2dynamodb_table_item.test",
+				Config:        testTableItemResourceConfig(fake.URL, `{ pk = "a", sk = 1 }`),
+				ImportState:   true,
+				ImportStateId: `orders/{"pk":"a"}`,
+				ExpectError:   regexp.MustCompile(`must have exactly the key attributes of table\s+"orders": "pk" and "sk"`),
+			},
+		},
+	})
+}
+
+func TestTableItemResource_importEndpoint(t *testing.T) {
+	fake := newFakeDynamoDB(t)
+	fake.createTable("orders", "pk", "sk")
+	// The endpoint of the provider is another server, without the table.
+	newFakeDynamoDB(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		CheckDestroy: func(*terraform.State) error {
+			return fake.checkItems("orders", nil)()
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testTableItemResourceConfig(fake.URL, `{ pk = "a", sk = 1, total = 2 }`),
+			},
+			{
+				ResourceName:            "json2dynamodb_table_item.test",
+				ImportState:             true,
+				ImportStateId:           fake.URL + `|orders/{"pk":"a","sk":1}`,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"type_hints"},
+			},
+			{
+				ResourceName:  "json2dynamodb_table_item.test",
+				ImportState:   true,
+				ImportStateId: `orders/{"pk":"a","sk":1}`,
+				ExpectError:   regexp.MustCompile(`DynamoDB DescribeTable Failed`),
+			},
+			{
+				ResourceName:  "json2dynamodb_table_item.test",
+				ImportState:   true,
+				ImportStateId: `|orders/{"pk":"a","sk":1}`,
+				ExpectError:   regexp.MustCompile(`expected an endpoint before "\|"`),
+			},
+		},
+	})
+}
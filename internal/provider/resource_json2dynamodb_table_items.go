@@ -0,0 +1,505 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	awstypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &JSON2DynamoDBTableItemsResource{}
+var _ resource.ResourceWithConfigure = &JSON2DynamoDBTableItemsResource{}
+var _ resource.ResourceWithModifyPlan = &JSON2DynamoDBTableItemsResource{}
+
+func NewJSON2DynamoDBTableItemsResource() resource.Resource {
+	return &JSON2DynamoDBTableItemsResource{}
+}
+
+// JSON2DynamoDBTableItemsResource defines the resource implementation.
+type JSON2DynamoDBTableItemsResource struct {
+	client *dynamoDBClient
+}
+
+// JSON2DynamoDBTableItemsResourceModel describes the resource data model.
+type JSON2DynamoDBTableItemsResourceModel struct {
+	TableName          types.String `tfsdk:"table_name"`
+	HashKey            types.String `tfsdk:"hash_key"`
+	RangeKey           types.String `tfsdk:"range_key"`
+	JSON               types.String `tfsdk:"json"`
+	TypeHints          types.Map    `tfsdk:"type_hints"`
+	DeleteRemovedItems types.Bool   `tfsdk:"delete_removed_items"`
+	Endpoint           types.String `tfsdk:"endpoint"`
+	ItemHashes         types.Map    `tfsdk:"item_hashes"`
+	Id                 types.String `tfsdk:"id"`
+}
+
+func (r *JSON2DynamoDBTableItemsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_table_items"
+}
+
+func (r *JSON2DynamoDBTableItemsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: fmt.Sprintf("Manages a collection of items of a DynamoDB table, given as a JSON array. Items are identified by their key attributes: only the items that are added, changed or removed are written, with BatchWriteItem in batches of %d, and items DynamoDB leaves unprocessed are retried with exponential backoff. Items read back from the table are tracked as a content hash per item in `item_hashes` rather than as a copy of each item, and items changed outside Terraform show up as differences in `item_hashes`. `json` is write-only, so neither the plan nor the state holds a copy of the items and they stay small however large the collection is; this requires Terraform 1.11 or later. Items that already exist in the table when they are added are overwritten.", batchWriteItemLimit),
+
+		Attributes: map[string]schema.Attribute{
+			"table_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the table",
+				Required:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"hash_key": schema.StringAttribute{
+				MarkdownDescription: "Name of the hash (partition) key attribute of the table",
+				Required:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"range_key": schema.StringAttribute{
+				MarkdownDescription: "Name of the range (sort) key attribute of the table, if it has one",
+				Optional:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"json": schema.StringAttribute{
+				MarkdownDescription: "JSON array of items, or JSON Lines with one item per line, each including its key attributes. Keys must be unique. Write-only: changes to the items show up as differences in `item_hashes`.",
+				Required:            true,
+				WriteOnly:           true,
+			},
+			"type_hints": schema.MapAttribute{
+				MarkdownDescription: "Map of JSON Pointer patterns to the DynamoDB type the matching values of each item are encoded as, as for the `json2dynamodb_items` data source",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"delete_removed_items": schema.BoolAttribute{
+				MarkdownDescription: "Delete the items removed from `json` from the table, and all the items when the resource is destroyed. Otherwise they are only no longer managed. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"endpoint": schema.StringAttribute{
+				MarkdownDescription: "URL of the DynamoDB endpoint, e.g. `http://localhost:8000` for DynamoDB Local. Defaults to the `endpoint` of the provider.",
+				Optional:            true,
+			},
+			"item_hashes": schema.MapAttribute{
+				MarkdownDescription: "SHA-256 hash of the content of each item, by the key of the item in plain JSON, e.g. `{\"pk\":\"a\",\"sk\":1}`. Numbers of keys are written without exponent or trailing zeros, as keys such as `1` and `1.0` are the same DynamoDB key.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The table name",
+				Computed:            true,
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+		},
+	}
+}
+
+func (r *JSON2DynamoDBTableItemsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	pd, diags := configuredProviderData(req.ProviderData)
+	resp.Diagnostics.Append(diags...)
+
+	if pd != nil {
+		r.client = pd.dynamodb
+	}
+}
+
+// encodeItems converts the items into DynamoDB attribute values, and returns
+// them and their hashes by key ID.
+func (m *JSON2DynamoDBTableItemsResourceModel) encodeItems(ctx context.Context) (map[string]map[string]awstypes.AttributeValue, map[string]string, diag.Diagnostics) {
+	var typeHints map[string]string
+	diags := m.TypeHints.ElementsAs(ctx, &typeHints, false)
+
+	if diags.HasError() {
+		return nil, nil, diags
+	}
+
+	encoder, encoderDiags := newItemEncoder(encodeOptions{TypeHints: typeHints})
+	diags.Append(encoderDiags...)
+
+	if diags.HasError() {
+		return nil, nil, diags
+	}
+
+	converted, itemsDiags := encoder.encodeJSONItems(m.JSON.ValueString())
+	diags.Append(itemsDiags...)
+
+	if diags.HasError() {
+		return nil, nil, diags
+	}
+
+	items := make(map[string]map[string]awstypes.AttributeValue, len(converted))
+	hashes := make(map[string]string, len(converted))
+	indexes := make(map[string]int, len(converted))
+	for i, item := range converted {
+		key, err := tableItemKey(item, m.HashKey.ValueString(), m.RangeKey.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("json"),
+				"Invalid Key Attribute",
+				fmt.Sprintf("Item %d cannot be written to the table.\n\nError: %s.", i, err),
+			)
+			continue
+		}
+		id, err := itemKeyID(key)
+		var hash string
+		if err == nil {
+			hash, err = itemHash(item)
+		}
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("json"),
+				"DynamoDB Item Hashing Failed",
+				fmt.Sprintf("The provider received an unexpected error while attempting to hash item %d.\n\nError: %s", i, err),
+			)
+			continue
+		}
+		if j, ok := indexes[id]; ok {
+			diags.AddAttributeError(
+				path.Root("json"),
+				"Duplicate Item Key",
+				fmt.Sprintf("Items %d and %d have the same key %s. Each item of the collection must have its own key.", j, i, id),
+			)
+			continue
+		}
+		indexes[id] = i
+		items[id] = item
+		hashes[id] = hash
+	}
+	return items, hashes, diags
+}
+
+// itemHashes returns the hashes of the items in the state by key ID. The IDs
+// are parsed and rendered again, so they compare equal to the IDs of the
+// configured items whatever the formatting of their numbers.
+func (m *JSON2DynamoDBTableItemsResourceModel) itemHashes(ctx context.Context) (map[string]string, diag.Diagnostics) {
+	stored := map[string]string{}
+	diags := m.ItemHashes.ElementsAs(ctx, &stored, false)
+
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	ids := slices.Sorted(maps.Keys(stored))
+	keys, keyDiags := m.keys(ctx, ids)
+	diags.Append(keyDiags...)
+
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	hashes := make(map[string]string, len(ids))
+	for i, key := range keys {
+		id, err := itemKeyID(key)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("item_hashes"),
+				"Invalid Item Key",
+				fmt.Sprintf("The key %s of the state cannot be read.\n\nError: %s", ids[i], err),
+			)
+			return nil, diags
+		}
+		hashes[id] = stored[ids[i]]
+	}
+	return hashes, diags
+}
+
+// keys converts key IDs back into the keys of the items.
+func (m *JSON2DynamoDBTableItemsResourceModel) keys(ctx context.Context, ids []string) ([]map[string]awstypes.AttributeValue, diag.Diagnostics) {
+	var typeHints map[string]string
+	diags := m.TypeHints.ElementsAs(ctx, &typeHints, false)
+
+	keys := make([]map[string]awstypes.AttributeValue, 0, len(ids))
+	for _, id := range ids {
+		if diags.HasError() {
+			return nil, diags
+		}
+		key, keyDiags := encodeItemJSON(id, encodeOptions{TypeHints: typeHints})
+		diags.Append(attributeDiagnostics(path.Root("item_hashes"), keyDiags)...)
+		keys = append(keys, key)
+	}
+	return keys, diags
+}
+
+// deleteRequests returns requests deleting the items with the given key IDs.
+func (m *JSON2DynamoDBTableItemsResourceModel) deleteRequests(ctx context.Context, ids []string) ([]awstypes.WriteRequest, diag.Diagnostics) {
+	keys, diags := m.keys(ctx, ids)
+	requests := make([]awstypes.WriteRequest, 0, len(keys))
+	for _, key := range keys {
+		requests = append(requests, awstypes.WriteRequest{DeleteRequest: &awstypes.DeleteRequest{Key: key}})
+	}
+	return requests, diags
+}
+
+// putRequests returns requests writing the items with the given key IDs, in
+// key order.
+func putRequests(items map[string]map[string]awstypes.AttributeValue, ids []string) []awstypes.WriteRequest {
+	requests := make([]awstypes.WriteRequest, 0, len(ids))
+	for _, id := range ids {
+		requests = append(requests, awstypes.WriteRequest{PutRequest: &awstypes.PutRequest{Item: items[id]}})
+	}
+	return requests
+}
+
+// write applies write requests to the table of the collection.
+func (r *JSON2DynamoDBTableItemsResource) write(ctx context.Context, data *JSON2DynamoDBTableItemsResourceModel, requests []awstypes.WriteRequest) diag.Diagnostics {
+	client, diags := r.client.get(ctx, data.Endpoint.ValueString())
+
+	if diags.HasError() || len(requests) == 0 {
+		return diags
+	}
+
+	if err := batchWriteItems(ctx, client, data.TableName.ValueString(), requests); err != nil {
+		diags.AddError(
+			"DynamoDB BatchWriteItem Failed",
+			fmt.Sprintf("The provider received an unexpected error while attempting to write the items.\n\nError: %s", err),
+		)
+	}
+	return diags
+}
+
+func (r *JSON2DynamoDBTableItemsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan JSON2DynamoDBTableItemsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	// json is write-only, so it is only in the configuration.
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("json"), &plan.JSON)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A change of json alone does not show up in the plan, so the hashes are
+	// planned as unknown until the items are known.
+	if plan.JSON.IsUnknown() || plan.TypeHints.IsUnknown() || plan.HashKey.IsUnknown() || plan.RangeKey.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("item_hashes"), types.MapUnknown(types.StringType))...)
+		return
+	}
+
+	// Plan the hashes of the configured items, so a difference shows up for
+	// each item that is added, changed or removed.
+	_, hashes, diags := plan.encodeItems(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	itemHashes, diags := types.MapValueFrom(ctx, types.StringType, hashes)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("item_hashes"), itemHashes)...)
+}
+
+func (r *JSON2DynamoDBTableItemsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data JSON2DynamoDBTableItemsResourceModel
+
+	// Read Terraform plan data, and the write-only json of the
+	// configuration, into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("json"), &data.JSON)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	items, hashes, diags := data.encodeItems(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.write(ctx, &data, putRequests(items, slices.Sorted(maps.Keys(items))))...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ItemHashes, diags = types.MapValueFrom(ctx, types.StringType, hashes)
+	resp.Diagnostics.Append(diags...)
+	data.Id = data.TableName
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *JSON2DynamoDBTableItemsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data JSON2DynamoDBTableItemsResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hashes, diags := data.itemHashes(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	ids := slices.Sorted(maps.Keys(hashes))
+	keys, diags := data.keys(ctx, ids)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := r.client.get(ctx, data.Endpoint.ValueString())
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := batchGetItems(ctx, client, data.TableName.ValueString(), keys)
+	var notFound *awstypes.ResourceNotFoundException
+	if errors.As(err, &notFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"DynamoDB BatchGetItem Failed",
+			fmt.Sprintf("The provider received an unexpected error while attempting to read the items.\n\nError: %s", err),
+		)
+		return
+	}
+
+	// Items deleted outside Terraform are left out, and planned to be
+	// written again.
+	storedHashes := make(map[string]string, len(stored))
+	for _, item := range stored {
+		key, err := tableItemKey(item, data.HashKey.ValueString(), data.RangeKey.ValueString())
+		var id, hash string
+		if err == nil {
+			id, err = itemKeyID(key)
+		}
+		if err == nil {
+			hash, err = itemHash(item)
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"DynamoDB Item Hashing Failed",
+				fmt.Sprintf("The provider received an unexpected error while attempting to hash a stored item.\n\nError: %s", err),
+			)
+			return
+		}
+		if _, ok := hashes[id]; ok {
+			storedHashes[id] = hash
+		}
+	}
+
+	data.ItemHashes, diags = types.MapValueFrom(ctx, types.StringType, storedHashes)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *JSON2DynamoDBTableItemsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state JSON2DynamoDBTableItemsResourceModel
+
+	// Read Terraform plan data, the write-only json of the configuration and
+	// prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("json"), &data.JSON)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	items, hashes, diags := data.encodeItems(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	stateHashes, diags := state.itemHashes(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Write the items that are new or changed, and delete the removed ones
+	// with the type hints they were written with.
+	var changed, removed []string
+	for _, id := range slices.Sorted(maps.Keys(hashes)) {
+		if stateHashes[id] != hashes[id] {
+			changed = append(changed, id)
+		}
+	}
+	for _, id := range slices.Sorted(maps.Keys(stateHashes)) {
+		if _, ok := hashes[id]; !ok {
+			removed = append(removed, id)
+		}
+	}
+
+	requests := putRequests(items, changed)
+	if data.DeleteRemovedItems.ValueBool() {
+		deletes, diags := state.deleteRequests(ctx, removed)
+		resp.Diagnostics.Append(diags...)
+		requests = append(requests, deletes...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.write(ctx, &data, requests)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ItemHashes, diags = types.MapValueFrom(ctx, types.StringType, hashes)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *JSON2DynamoDBTableItemsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data JSON2DynamoDBTableItemsResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() || !data.DeleteRemovedItems.ValueBool() {
+		return
+	}
+
+	hashes, diags := data.itemHashes(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	requests, diags := data.deleteRequests(ctx, slices.Sorted(maps.Keys(hashes)))
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := r.client.get(ctx, data.Endpoint.ValueString())
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The items are gone already when their table is.
+	err := batchWriteItems(ctx, client, data.TableName.ValueString(), requests)
+	var notFound *awstypes.ResourceNotFoundException
+	if err != nil && !errors.As(err, &notFound) {
+		resp.Diagnostics.AddError(
+			"DynamoDB BatchWriteItem Failed",
+			fmt.Sprintf("The provider received an unexpected error while attempting to delete the items.\n\nError: %s", err),
+		)
+	}
+}
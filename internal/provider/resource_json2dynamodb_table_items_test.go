@@ -0,0 +1,260 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func testTableItemsResourceConfig(endpoint, items, options string) string {
+	return fmt.Sprintf(`
+resource "json2dynamodb_table_items" "test" {
+  table_name = "orders"
+  hash_key   = "pk"
+  range_key  = "sk"
+  endpoint   = %q
+
+  json       = jsonencode(%s)
+  type_hints = { "/tags" = "SS" }
+%s
+}
+`, endpoint, items, options)
+}
+
+// testTableItemsResourceConfig_json passes the items as JSON text, so their
+// numbers keep the formatting they are written with.
+func testTableItemsResourceConfig_json(endpoint, items string) string {
+	return fmt.Sprintf(`
+resource "json2dynamodb_table_items" "test" {
+  table_name = "orders"
+  hash_key   = "pk"
+  range_key  = "sk"
+  endpoint   = %q
+
+  json = <<EOF
+%s
+EOF
+}
+`, endpoint, items)
+}
+
+func TestTableItemsResource_basic(t *testing.T) {
+	fake := newFakeDynamoDB(t)
+	fake.createTable("orders", "pk", "sk")
+	// The first two batches leave a request unprocessed, to be retried.
+	fake.unprocessed = 2
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		// json is write-only.
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		CheckDestroy: func(*terraform.State) error {
+			// Items removed with delete_removed_items = false are left.
+			return fake.checkItems("orders", map[string]string{
+				`{"pk":{"S":"a"},"sk":{"N":"3"}}`: `{"pk":{"S":"a"},"sk":{"N":"3"},"total":{"N":"3"}}`,
+			})()
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testTableItemsResourceConfig(fake.URL, `[
+    { pk = "a", sk = 1, total = 1.5, tags = ["y", "x"] },
+    { pk = "a", sk = 2, total = 2 },
+    { pk = "b", sk = 1, total = 1 },
+  ]`, ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("json2dynamodb_table_items.test", "id", "orders"),
+					resource.TestCheckNoResourceAttr("json2dynamodb_table_items.test", "json"),
+					resource.TestCheckResourceAttr("json2dynamodb_table_items.test", "delete_removed_items", "true"),
+					resource.TestCheckResourceAttr("json2dynamodb_table_items.test", "item_hashes.%", "3"),
+					resource.TestCheckResourceAttrSet("json2dynamodb_table_items.test", `item_hashes.{"pk":"a","sk":1}`),
+					func(*terraform.State) error {
+						return fake.checkItems("orders", map[string]string{
+							`{"pk":{"S":"a"},"sk":{"N":"1"}}`: `{"pk":{"S":"a"},"sk":{"N":"1"},"tags":{"SS":["y","x"]},"total":{"N":"1.5"}}`,
+							`{"pk":{"S":"a"},"sk":{"N":"2"}}`: `{"pk":{"S":"a"},"sk":{"N":"2"},"total":{"N":"2"}}`,
+							`{"pk":{"S":"b"},"sk":{"N":"1"}}`: `{"pk":{"S":"b"},"sk":{"N":"1"},"total":{"N":"1"}}`,
+						})()
+					},
+				),
+			},
+			// Changes made outside Terraform show up as a difference in
+			// item_hashes, but not the order of set members or the formatting
+			// of numbers.
+			{
+				PreConfig: func() {
+					fake.putItem(t, "orders", `{"pk":{"S":"a"},"sk":{"N":"1"},"tags":{"SS":["x","y"]},"total":{"N":"1.50"}}`)
+				},
+				Config: testTableItemsResourceConfig(fake.URL, `[
+    { pk = "a", sk = 1, total = 1.5, tags = ["y", "x"] },
+    { pk = "a", sk = 2, total = 2 },
+    { pk = "b", sk = 1, total = 1 },
+  ]`, ""),
+				PlanOnly: true,
+			},
+			{
+				PreConfig: func() {
+					fake.putItem(t, "orders", `{"pk":{"S":"a"},"sk":{"N":"2"},"total":{"N":"5"}}`)
+				},
+				Config: testTableItemsResourceConfig(fake.URL, `[
+    { pk = "a", sk = 1, total = 1.5, tags = ["y", "x"] },
+    { pk = "a", sk = 2, total = 2 },
+    { pk = "b", sk = 1, total = 1 },
+  ]`, ""),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Changed and added items are written, the drifted item is
+			// restored and removed items are deleted.
+			{
+				Config: testTableItemsResourceConfig(fake.URL, `[
+    { pk = "a", sk = 1, total = 1.5, tags = ["y", "x"] },
+    { pk = "a", sk = 2, total = 2 },
+    { pk = "a", sk = 3, total = 3 },
+    { pk = "b", sk = 1, total = 4 },
+  ]`, ""),
+				Check: func(*terraform.State) error {
+					return fake.checkItems("orders", map[string]string{
+						`{"pk":{"S":"a"},"sk":{"N":"1"}}`: `{"pk":{"S":"a"},"sk":{"N":"1"},"tags":{"SS":["x","y"]},"total":{"N":"1.50"}}`,
+						`{"pk":{"S":"a"},"sk":{"N":"2"}}`: `{"pk":{"S":"a"},"sk":{"N":"2"},"total":{"N":"2"}}`,
+						`{"pk":{"S":"a"},"sk":{"N":"3"}}`: `{"pk":{"S":"a"},"sk":{"N":"3"},"total":{"N":"3"}}`,
+						`{"pk":{"S":"b"},"sk":{"N":"1"}}`: `{"pk":{"S":"b"},"sk":{"N":"1"},"total":{"N":"4"}}`,
+					})()
+				},
+			},
+			{
+				Config: testTableItemsResourceConfig(fake.URL, `[
+    { pk = "a", sk = 1, total = 1.5, tags = ["y", "x"] },
+    { pk = "b", sk = 1, total = 4 },
+  ]`, ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("json2dynamodb_table_items.test", "item_hashes.%", "2"),
+					func(*terraform.State) error {
+						return fake.checkItems("orders", map[string]string{
+							`{"pk":{"S":"a"},"sk":{"N":"1"}}`: `{"pk":{"S":"a"},"sk":{"N":"1"},"tags":{"SS":["x","y"]},"total":{"N":"1.50"}}`,
+							`{"pk":{"S":"b"},"sk":{"N":"1"}}`: `{"pk":{"S":"b"},"sk":{"N":"1"},"total":{"N":"4"}}`,
+						})()
+					},
+				),
+			},
+			// Items deleted outside Terraform are written again.
+			{
+				PreConfig: func() {
+					fake.putItem(t, "orders", `{"pk":{"S":"a"},"sk":{"N":"3"},"total":{"N":"3"}}`)
+				},
+				Config: testTableItemsResourceConfig(fake.URL, `[
+    { pk = "a", sk = 1, total = 1.5, tags = ["y", "x"] },
+    { pk = "a", sk = 3, total = 3 },
+    { pk = "b", sk = 1, total = 4 },
+  ]`, ""),
+				Check: resource.TestCheckResourceAttr("json2dynamodb_table_items.test", "item_hashes.%", "3"),
+			},
+			{
+				Config: testTableItemsResourceConfig(fake.URL, `[
+    { pk = "a", sk = 1, total = 1.5, tags = ["y", "x"] },
+    { pk = "b", sk = 1, total = 4 },
+  ]`, `  delete_removed_items = false`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("json2dynamodb_table_items.test", "item_hashes.%", "2"),
+					func(*terraform.State) error {
+						return fake.checkItems("orders", map[string]string{
+							`{"pk":{"S":"a"},"sk":{"N":"1"}}`: `{"pk":{"S":"a"},"sk":{"N":"1"},"tags":{"SS":["x","y"]},"total":{"N":"1.50"}}`,
+							`{"pk":{"S":"a"},"sk":{"N":"3"}}`: `{"pk":{"S":"a"},"sk":{"N":"3"},"total":{"N":"3"}}`,
+							`{"pk":{"S":"b"},"sk":{"N":"1"}}`: `{"pk":{"S":"b"},"sk":{"N":"1"},"total":{"N":"4"}}`,
+						})()
+					},
+				),
+			},
+			// Only the managed items are deleted on destroy.
+			{
+				Config: testTableItemsResourceConfig(fake.URL, `[
+    { pk = "a", sk = 1, total = 1.5, tags = ["y", "x"] },
+    { pk = "b", sk = 1, total = 4 },
+  ]`, ""),
+				Check: resource.TestCheckResourceAttr("json2dynamodb_table_items.test", "delete_removed_items", "true"),
+			},
+		},
+	})
+}
+
+func TestTableItemsResource_invalid(t *testing.T) {
+	fake := newFakeDynamoDB(t)
+	fake.createTable("orders", "pk", "sk")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		// json is write-only.
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config:      testTableItemsResourceConfig(fake.URL, `[{ pk = "a", sk = 1 }, { pk = "a", sk = 1, total = 1 }]`, ""),
+				ExpectError: regexp.MustCompile(`Items 0 and 1 have the same key\s+\{"pk":"a","sk":1\}`),
+			},
+			// Keys that only differ in the formatting of their numbers are the
+			// same DynamoDB key.
+			{
+				Config:      testTableItemsResourceConfig_json(fake.URL, `[{"pk": "a", "sk": 1}, {"pk": "a", "sk": 1.0, "total": 1}]`),
+				ExpectError: regexp.MustCompile(`Items 0 and 1 have the same key\s+\{"pk":"a","sk":1\}`),
+			},
+			{
+				Config:      testTableItemsResourceConfig(fake.URL, `[{ pk = "a", sk = 1 }, { pk = "a" }]`, ""),
+				ExpectError: regexp.MustCompile(`Item 1 cannot be written to the table`),
+			},
+		},
+	})
+}
+
+func TestTableItemsResource_numberKeys(t *testing.T) {
+	fake := newFakeDynamoDB(t)
+	fake.createTable("orders", "pk", "sk")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		// json is write-only.
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		CheckDestroy: func(*terraform.State) error {
+			return fake.checkItems("orders", nil)()
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testTableItemsResourceConfig_json(fake.URL, `[{"pk": "a", "sk": 1.0, "v": 1}, {"pk": "a", "sk": 2, "v": 2}]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("json2dynamodb_table_items.test", "item_hashes.%", "2"),
+					resource.TestCheckResourceAttrSet("json2dynamodb_table_items.test", `item_hashes.{"pk":"a","sk":1}`),
+					func(*terraform.State) error {
+						return fake.checkItems("orders", map[string]string{
+							`{"pk":{"S":"a"},"sk":{"N":"1"}}`: `{"pk":{"S":"a"},"sk":{"N":"1.0"},"v":{"N":"1"}}`,
+							`{"pk":{"S":"a"},"sk":{"N":"2"}}`: `{"pk":{"S":"a"},"sk":{"N":"2"},"v":{"N":"2"}}`,
+						})()
+					},
+				),
+			},
+			// Rewriting 1.0 as 1 changes neither the key nor the item.
+			{
+				Config:   testTableItemsResourceConfig_json(fake.URL, `[{"pk": "a", "sk": 1, "v": 1}, {"pk": "a", "sk": 2, "v": 2}]`),
+				PlanOnly: true,
+			},
+			// A changed item is put under its key, not put and deleted.
+			{
+				Config: testTableItemsResourceConfig_json(fake.URL, `[{"pk": "a", "sk": 1, "v": 3}, {"pk": "a", "sk": 2, "v": 2}]`),
+				Check: func(*terraform.State) error {
+					return fake.checkItems("orders", map[string]string{
+						`{"pk":{"S":"a"},"sk":{"N":"1"}}`: `{"pk":{"S":"a"},"sk":{"N":"1"},"v":{"N":"3"}}`,
+						`{"pk":{"S":"a"},"sk":{"N":"2"}}`: `{"pk":{"S":"a"},"sk":{"N":"2"},"v":{"N":"2"}}`,
+					})()
+				},
+			},
+		},
+	})
+}
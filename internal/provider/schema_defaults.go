@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math/big"
+	"slices"
+	"strconv"
+
+	"github.com/go-openapi/spec"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/validate"
+)
+
+// schemaRewriter fills in the defaults of a JSON Schema and coerces strings
+// to the types it declares, by walking it alongside the document it
+// describes.
+type schemaRewriter struct {
+	// defaults fills in missing properties from their default.
+	defaults bool
+	// coerce converts numeric and boolean strings where the schema expects
+	// a number or boolean.
+	coerce bool
+
+	changes []string
+}
+
+// applySchema rewrites doc as the schema says and returns it, with a
+// description of each change prefixed by the JSON Pointer it was made at:
+//
+//   - missing properties with a default are set to it when defaults is set,
+//     and their own properties are filled in in turn
+//   - strings holding a JSON number are converted to numbers where the schema
+//     expects an integer or number but not a string, when coerce is set
+//   - "true" and "false" are converted to booleans where the schema expects
+//     a boolean but not a string, when coerce is set
+//
+// Objects and arrays of doc are rewritten in place.
+func applySchema(schema *spec.Schema, doc interface{}, defaults, coerce bool) (interface{}, []string, error) {
+	r := &schemaRewriter{defaults: defaults, coerce: coerce}
+	doc, err := r.walk(schema, doc, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	return doc, r.changes, nil
+}
+
+func (r *schemaRewriter) walk(s *spec.Schema, v interface{}, segments []string) (interface{}, error) {
+	if s == nil {
+		return v, nil
+	}
+
+	var err error
+	for i := range s.AllOf {
+		if v, err = r.walk(&s.AllOf[i], v, segments); err != nil {
+			return nil, err
+		}
+	}
+	// Follow the first alternative the value actually matches.
+	for _, alternatives := range [][]spec.Schema{s.AnyOf, s.OneOf} {
+		for i := range alternatives {
+			if validate.AgainstSchema(&alternatives[i], floatNumbers(v), strfmt.Default) == nil {
+				if v, err = r.walk(&alternatives[i], v, segments); err != nil {
+					return nil, err
+				}
+				break
+			}
+		}
+	}
+
+	if r.coerce {
+		v = r.coerceType(s, v, segments)
+	}
+
+	switch uv := v.(type) {
+	case map[string]interface{}:
+		if r.defaults {
+			for _, k := range slices.Sorted(maps.Keys(s.Properties)) {
+				p := s.Properties[k]
+				if _, ok := uv[k]; ok || p.Default == nil {
+					continue
+				}
+				d, err := defaultValue(p.Default)
+				if err != nil {
+					return nil, fmt.Errorf("at %s: %w", formatPointer(append(slices.Clip(segments), k)), err)
+				}
+				uv[k] = d
+				r.record(append(slices.Clip(segments), k), "set default %s", violationValue(d))
+			}
+		}
+		for _, k := range slices.Sorted(maps.Keys(uv)) {
+			if uv[k], err = r.walk(propertySchema(s, k), uv[k], append(slices.Clip(segments), k)); err != nil {
+				return nil, err
+			}
+		}
+	case []interface{}:
+		for i, e := range uv {
+			if uv[i], err = r.walk(itemSchema(s, i), e, append(slices.Clip(segments), strconv.Itoa(i))); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return v, nil
+}
+
+// coerceType converts a string into the number or boolean the schema
+// expects, if it holds one.
+func (r *schemaRewriter) coerceType(s *spec.Schema, v interface{}, segments []string) interface{} {
+	str, ok := v.(string)
+	if !ok || s.Type.Contains("string") {
+		return v
+	}
+
+	var coerced interface{}
+	switch {
+	case s.Type.Contains("number") && jsonNumberPattern.MatchString(str):
+		coerced = json.Number(str)
+	case s.Type.Contains("integer") && jsonNumberPattern.MatchString(str):
+		if n, ok := new(big.Rat).SetString(str); ok && n.IsInt() {
+			coerced = json.Number(str)
+		}
+	case s.Type.Contains("boolean") && (str == "true" || str == "false"):
+		coerced = str == "true"
+	}
+	if coerced == nil {
+		return v
+	}
+	r.record(segments, "coerced %s to %s", violationValue(v), violationValue(coerced))
+	return coerced
+}
+
+func (r *schemaRewriter) record(segments []string, format string, args ...interface{}) {
+	r.changes = append(r.changes, formatPointer(segments)+": "+fmt.Sprintf(format, args...))
+}
+
+// defaultValue returns a copy of a default as decoded JSON, with numbers as
+// json.Number like the rest of the document.
+func defaultValue(d interface{}) (interface{}, error) {
+	raw, err := json.Marshal(d)
+	if err != nil {
+		return nil, fmt.Errorf("invalid default: %w", err)
+	}
+	var v interface{}
+	if err := unmarshalJSONNumbers(raw, &v); err != nil {
+		return nil, fmt.Errorf("invalid default: %w", err)
+	}
+	return v, nil
+}
@@ -0,0 +1,293 @@
+package provider
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// inferDialects are the JSON Schema drafts a schema can be inferred as, with
+// the $schema they are identified by.
+var inferDialects = map[string]string{
+	"draft-07": "http://json-schema.org/draft-07/schema#",
+	"2020-12":  "https://json-schema.org/draft/2020-12/schema",
+}
+
+// defaultInferDialect is the draft of inferred schemas.
+const defaultInferDialect = "draft-07"
+
+// defaultMaxEnumValues is the most distinct values a string may take in the
+// samples to be inferred as an enum.
+const defaultMaxEnumValues = 5
+
+// Formats inferred for strings.
+const (
+	inferFormatDateTime = "date-time"
+	inferFormatUUID     = "uuid"
+	// inferFormatByte is the OpenAPI format of base64 strings, which
+	// schema_types encodes as B.
+	inferFormatByte = "byte"
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// inferOptions controls how a JSON Schema is inferred from sample items.
+type inferOptions struct {
+	// SchemaDialect is the draft of the schema, defaultInferDialect if empty.
+	SchemaDialect string
+	// MaxEnumValues is the most distinct values a string may take to be
+	// inferred as an enum. Zero disables enums.
+	MaxEnumValues int
+}
+
+// schemaShape accumulates the values observed at one location of the
+// samples.
+type schemaShape struct {
+	// types counts the values of each JSON Schema type.
+	types map[string]int
+	// objects counts the objects, and properties the values of their
+	// properties. A property is required when it is in every object.
+	objects    int
+	properties map[string]*schemaShape
+	// items accumulates the members of every array.
+	items *schemaShape
+	// values counts the distinct strings, up to one more than the enum limit.
+	values map[string]int
+	// formats counts the strings in each format, "" for none.
+	formats map[string]int
+}
+
+func newSchemaShape() *schemaShape {
+	return &schemaShape{
+		types:      make(map[string]int),
+		properties: make(map[string]*schemaShape),
+		values:     make(map[string]int),
+		formats:    make(map[string]int),
+	}
+}
+
+// inferSchema infers a JSON Schema from a JSON array or JSON Lines stream of
+// sample items. Diagnostics are reported against the "json" and
+// "schema_dialect" attributes.
+func inferSchema(input string, opts inferOptions) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if opts.SchemaDialect == "" {
+		opts.SchemaDialect = defaultInferDialect
+	}
+	schemaURI, ok := inferDialects[opts.SchemaDialect]
+	if !ok {
+		diags.AddAttributeError(
+			path.Root("schema_dialect"),
+			"Invalid Schema Dialect",
+			fmt.Sprintf("Expected one of %s, got: %q.", strings.Join(slices.Sorted(maps.Keys(inferDialects)), ", "), opts.SchemaDialect),
+		)
+		return "", diags
+	}
+
+	items, err := unmarshalJSONItems([]byte(input))
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("json"),
+			"JSON Handling Failed",
+			fmt.Sprintf("The provider received an unexpected error while attempting to parse the JSON items.\n\nError: %s", err),
+		)
+		return "", diags
+	}
+	if len(items) == 0 {
+		diags.AddAttributeError(
+			path.Root("json"),
+			"Missing Sample Items",
+			"At least one sample item is required to infer a schema.",
+		)
+		return "", diags
+	}
+
+	shape := newSchemaShape()
+	for i, item := range items {
+		if _, ok := item.(map[string]interface{}); !ok {
+			diags.AddAttributeError(
+				path.Root("json"),
+				"Unsupported JSON Value",
+				fmt.Sprintf("Item %d: A DynamoDB item must be a JSON object, got: %s.", i, jsonTypeName(item)),
+			)
+			continue
+		}
+		shape.observe(item, opts.MaxEnumValues)
+	}
+	if diags.HasError() {
+		return "", diags
+	}
+
+	schema := shape.schema(opts.MaxEnumValues)
+	schema["$schema"] = schemaURI
+	result, err := json.Marshal(schema)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("json"),
+			"JSON Serialization Failed",
+			fmt.Sprintf("The provider received an unexpected error while attempting to render the JSON Schema.\n\nError: %s", err),
+		)
+		return "", diags
+	}
+	return string(result), diags
+}
+
+// observe records a decoded JSON value.
+func (s *schemaShape) observe(v interface{}, maxEnumValues int) {
+	switch uv := v.(type) {
+	case map[string]interface{}:
+		s.types["object"]++
+		s.objects++
+		for k, e := range uv {
+			p, ok := s.properties[k]
+			if !ok {
+				p = newSchemaShape()
+				s.properties[k] = p
+			}
+			p.observe(e, maxEnumValues)
+		}
+	case []interface{}:
+		s.types["array"]++
+		if s.items == nil {
+			s.items = newSchemaShape()
+		}
+		for _, e := range uv {
+			s.items.observe(e, maxEnumValues)
+		}
+	case string:
+		s.types["string"]++
+		s.formats[stringFormat(uv)]++
+		if _, ok := s.values[uv]; ok || len(s.values) <= maxEnumValues {
+			s.values[uv]++
+		}
+	case json.Number:
+		if strings.ContainsAny(uv.String(), ".eE") {
+			s.types["number"]++
+		} else {
+			s.types["integer"]++
+		}
+	case bool:
+		s.types["boolean"]++
+	case nil:
+		s.types["null"]++
+	}
+}
+
+// typeNames returns the JSON Schema types observed, with integer folded into
+// number when both were.
+func (s *schemaShape) typeNames() []string {
+	types := slices.Sorted(maps.Keys(s.types))
+	if s.types["integer"] > 0 && s.types["number"] > 0 {
+		types = slices.DeleteFunc(types, func(t string) bool { return t == "integer" })
+	}
+	return types
+}
+
+// schema renders the JSON Schema of the values observed.
+func (s *schemaShape) schema(maxEnumValues int) map[string]interface{} {
+	schema := make(map[string]interface{})
+
+	types := s.typeNames()
+	switch len(types) {
+	case 0:
+		// Only ever seen as a member of empty arrays.
+		return schema
+	case 1:
+		schema["type"] = types[0]
+	default:
+		schema["type"] = types
+	}
+
+	if s.objects > 0 {
+		properties := make(map[string]interface{}, len(s.properties))
+		var required []string
+		for k, p := range s.properties {
+			properties[k] = p.schema(maxEnumValues)
+			if p.present() == s.objects {
+				required = append(required, k)
+			}
+		}
+		schema["properties"] = properties
+		if len(required) > 0 {
+			slices.Sort(required)
+			schema["required"] = required
+		}
+	}
+
+	if s.items != nil && len(s.items.typeNames()) == 1 {
+		schema["items"] = s.items.schema(maxEnumValues)
+	}
+
+	if s.types["string"] > 0 {
+		if len(s.formats) == 1 {
+			for f := range s.formats {
+				if f != "" {
+					schema["format"] = f
+				}
+			}
+		}
+		// An enum is only inferred for strings without a format that repeat,
+		// so unique values such as names are not pinned to the samples.
+		if _, ok := schema["format"]; !ok && len(types) == 1 && len(s.values) <= maxEnumValues && len(s.values) < s.types["string"] {
+			schema["enum"] = slices.Sorted(maps.Keys(s.values))
+		}
+	}
+	return schema
+}
+
+// present returns the number of values observed.
+func (s *schemaShape) present() int {
+	n := 0
+	for _, c := range s.types {
+		n += c
+	}
+	return n
+}
+
+// stringFormat returns the format a string is in, or "" if none.
+func stringFormat(s string) string {
+	switch {
+	case isDateTime(s):
+		return inferFormatDateTime
+	case uuidPattern.MatchString(s):
+		return inferFormatUUID
+	case isBase64(s):
+		return inferFormatByte
+	}
+	return ""
+}
+
+func isDateTime(s string) bool {
+	_, err := time.Parse(time.RFC3339Nano, s)
+	return err == nil
+}
+
+// isBase64 reports whether a string looks like base64 encoded binary data.
+// Any string of letters whose length is a multiple of four is valid base64,
+// so only strings with padding, "+" or "/", or long strings mixing letters
+// and digits, are taken to be base64.
+func isBase64(s string) bool {
+	if len(s) == 0 || len(s)%4 != 0 {
+		return false
+	}
+	if _, err := base64.StdEncoding.DecodeString(s); err != nil {
+		return false
+	}
+	if strings.ContainsAny(s, "+/=") {
+		return true
+	}
+	return len(s) >= 16 && strings.ContainsAny(s, "0123456789") && strings.ContainsFunc(s, func(r rune) bool {
+		return r >= 'a' && r <= 'z'
+	}) && strings.ContainsFunc(s, func(r rune) bool {
+		return r >= 'A' && r <= 'Z'
+	})
+}
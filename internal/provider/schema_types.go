@@ -0,0 +1,196 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/go-openapi/spec"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/validate"
+)
+
+// schemaTypeExtension is the vendor extension that sets the DynamoDB type of
+// a value explicitly.
+const schemaTypeExtension = "x-dynamodb-type"
+
+// schemaTyper derives DynamoDB types from a JSON Schema by walking it
+// alongside the document it describes.
+type schemaTyper struct {
+	// epochDateTimes encodes format: date-time strings as N epoch seconds.
+	epochDateTimes bool
+
+	hints map[string]typeHint
+}
+
+// schemaTypeHints returns the DynamoDB types the schema implies for doc,
+// keyed by the JSON Pointer of each value:
+//
+//   - x-dynamodb-type sets the type explicitly
+//   - uniqueItems arrays of strings or numbers become SS or NS
+//   - uniqueItems arrays of format: byte or binary strings become BS
+//   - format: byte or binary strings become B
+//   - format: date-time strings become N epoch seconds when epochDateTimes
+//     is set, or when x-dynamodb-type is N
+//
+// Empty arrays are left as lists, as DynamoDB sets cannot be empty.
+func schemaTypeHints(schema *spec.Schema, doc interface{}, epochDateTimes bool) (map[string]typeHint, error) {
+	t := &schemaTyper{
+		epochDateTimes: epochDateTimes,
+		hints:          make(map[string]typeHint),
+	}
+	if err := t.walk(schema, doc, nil); err != nil {
+		return nil, err
+	}
+	return t.hints, nil
+}
+
+func (t *schemaTyper) walk(s *spec.Schema, v interface{}, segments []string) error {
+	if s == nil {
+		return nil
+	}
+
+	for i := range s.AllOf {
+		if err := t.walk(&s.AllOf[i], v, segments); err != nil {
+			return err
+		}
+	}
+	// Follow the first alternative the value actually matches.
+	for _, alternatives := range [][]spec.Schema{s.AnyOf, s.OneOf} {
+		for i := range alternatives {
+			if validate.AgainstSchema(&alternatives[i], floatNumbers(v), strfmt.Default) == nil {
+				if err := t.walk(&alternatives[i], v, segments); err != nil {
+					return err
+				}
+				break
+			}
+		}
+	}
+
+	hint, err := t.hint(s, v)
+	if err != nil {
+		return fmt.Errorf("at %s: %w", formatPointer(segments), err)
+	}
+	if hint != nil {
+		t.hints[formatPointer(segments)] = *hint
+		return nil
+	}
+
+	switch uv := v.(type) {
+	case map[string]interface{}:
+		for k, e := range uv {
+			if err := t.walk(propertySchema(s, k), e, append(slices.Clip(segments), k)); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for i, e := range uv {
+			if err := t.walk(itemSchema(s, i), e, append(slices.Clip(segments), strconv.Itoa(i))); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// hint returns the DynamoDB type the schema implies for v itself, if any.
+func (t *schemaTyper) hint(s *spec.Schema, v interface{}) (*typeHint, error) {
+	source := fmt.Sprintf("spec %s", schemaTypeExtension)
+	if ext, ok := s.Extensions.GetString(schemaTypeExtension); ok {
+		ext = strings.ToUpper(ext)
+		if !slices.Contains(typeHintTypes, ext) {
+			return nil, fmt.Errorf("%s %q is not supported, expected one of %s", schemaTypeExtension, ext, strings.Join(typeHintTypes, ", "))
+		}
+		return &typeHint{Type: ext, Source: source, FromDateTime: ext == attributeTypeN && s.Format == "date-time"}, nil
+	}
+
+	source = "spec"
+	switch uv := v.(type) {
+	case string:
+		switch {
+		case isBinaryFormat(s.Format):
+			return &typeHint{Type: attributeTypeB, Source: source}, nil
+		case s.Format == "date-time" && t.epochDateTimes:
+			return &typeHint{Type: attributeTypeN, Source: source, FromDateTime: true}, nil
+		}
+
+	case []interface{}:
+		if !s.UniqueItems || len(uv) == 0 || s.Items == nil || s.Items.Schema == nil {
+			break
+		}
+		items := s.Items.Schema
+		switch {
+		case items.Type.Contains("string") && isBinaryFormat(items.Format):
+			return &typeHint{Type: attributeTypeBS, Source: source}, nil
+		case items.Type.Contains("string"):
+			return &typeHint{Type: attributeTypeSS, Source: source}, nil
+		case items.Type.Contains("number") || items.Type.Contains("integer"):
+			return &typeHint{Type: attributeTypeNS, Source: source}, nil
+		}
+	}
+	return nil, nil
+}
+
+func isBinaryFormat(format string) bool {
+	return format == "byte" || format == "binary"
+}
+
+// propertySchema returns the schema that applies to the property k of an object.
+func propertySchema(s *spec.Schema, k string) *spec.Schema {
+	if p, ok := s.Properties[k]; ok {
+		return &p
+	}
+	for pattern, p := range s.PatternProperties {
+		if re, err := regexp.Compile(pattern); err == nil && re.MatchString(k) {
+			return &p
+		}
+	}
+	if s.AdditionalProperties != nil {
+		return s.AdditionalProperties.Schema
+	}
+	return nil
+}
+
+// itemSchema returns the schema that applies to the item i of an array.
+func itemSchema(s *spec.Schema, i int) *spec.Schema {
+	if s.Items == nil {
+		return nil
+	}
+	if s.Items.Schema != nil {
+		return s.Items.Schema
+	}
+	if i < len(s.Items.Schemas) {
+		return &s.Items.Schemas[i]
+	}
+	if s.AdditionalItems != nil {
+		return s.AdditionalItems.Schema
+	}
+	return nil
+}
+
+// floatNumbers returns a copy of a decoded JSON value with json.Number values
+// converted to float64, as expected by the go-openapi validator.
+func floatNumbers(v interface{}) interface{} {
+	switch uv := v.(type) {
+	case json.Number:
+		f, _ := uv.Float64()
+		return f
+	case []interface{}:
+		l := make([]interface{}, len(uv))
+		for i, e := range uv {
+			l[i] = floatNumbers(e)
+		}
+		return l
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(uv))
+		for k, e := range uv {
+			m[k] = floatNumbers(e)
+		}
+		return m
+	default:
+		return v
+	}
+}
@@ -0,0 +1,326 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/oasdiff/yaml"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// inlineSpecName is the file name spec is given when spec_dir is set, so its
+// relative references resolve against spec_dir.
+const inlineSpecName = "_inline_spec.json"
+
+// remoteSpecTimeout bounds fetching a remote spec document.
+const remoteSpecTimeout = 30 * time.Second
+
+// specCache reads spec documents from disk, and over HTTP(S) when remote
+// references are allowed. Documents are kept for the life of the provider
+// instance, so a spec shared by many data sources is read once.
+type specCache struct {
+	allowRemote bool
+	client      *http.Client
+
+	mu   sync.Mutex
+	docs map[string][]byte
+}
+
+func newSpecCache(allowRemote bool) *specCache {
+	return &specCache{
+		allowRemote: allowRemote,
+		client:      &http.Client{Timeout: remoteSpecTimeout},
+		docs:        make(map[string][]byte),
+	}
+}
+
+// read returns the document at a file, http or https URL.
+func (c *specCache) read(u *url.URL) ([]byte, error) {
+	key := *u
+	key.Fragment = ""
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if data, ok := c.docs[key.String()]; ok {
+		return data, nil
+	}
+
+	var data []byte
+	var err error
+	switch key.Scheme {
+	case "file":
+		data, err = os.ReadFile(filepath.FromSlash(key.Path))
+	case "http", "https":
+		if !c.allowRemote {
+			return nil, fmt.Errorf("cannot fetch %s: remote references are disabled, set allow_remote_refs in the provider configuration to enable them", key.String())
+		}
+		data, err = c.fetch(key.String())
+	default:
+		return nil, fmt.Errorf("cannot load %s: unsupported URL scheme %q", key.String(), key.Scheme)
+	}
+	if err != nil {
+		return nil, err
+	}
+	c.docs[key.String()] = data
+	return data, nil
+}
+
+func (c *specCache) fetch(u string) ([]byte, error) {
+	resp, err := c.client.Get(u)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("cannot fetch %s: %s", u, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// specSource is the root document of a spec and the documents it references.
+type specSource struct {
+	// url is the URL of the root document. Relative references resolve
+	// against it.
+	url  *url.URL
+	data []byte
+	// external is set when references to other documents may be followed.
+	external bool
+	cache    *specCache
+}
+
+// newSpecSource locates the root document of a spec: spec_file, resolved
+// against spec_dir when relative, or the inline spec, placed in spec_dir when
+// set. Without spec_file or spec_dir only the spec itself can be referenced.
+func newSpecSource(opts encodeOptions) (*specSource, error) {
+	cache := opts.Specs
+	if cache == nil {
+		cache = newSpecCache(false)
+	}
+	src := &specSource{cache: cache}
+
+	if opts.SpecFile == "" && opts.SpecDir == "" {
+		src.url, _ = url.Parse(specURL)
+		src.data = []byte(opts.Spec)
+		return src, nil
+	}
+	src.external = true
+
+	dir, err := filepath.Abs(opts.SpecDir)
+	if err != nil {
+		return nil, err
+	}
+	if opts.SpecFile == "" {
+		src.url = fileURL(filepath.Join(dir, inlineSpecName))
+		src.data = []byte(opts.Spec)
+		return src, nil
+	}
+
+	file := opts.SpecFile
+	if !filepath.IsAbs(file) {
+		file = filepath.Join(dir, file)
+	}
+	src.url = fileURL(file)
+	if src.data, err = cache.read(src.url); err != nil {
+		return nil, err
+	}
+	return src, nil
+}
+
+// fileURL returns the file URL of an absolute path.
+func fileURL(p string) *url.URL {
+	return &url.URL{Scheme: "file", Path: filepath.ToSlash(p)}
+}
+
+// read returns the raw document at a URL, which may be JSON or YAML.
+func (s *specSource) read(u *url.URL) ([]byte, error) {
+	if u.Scheme == s.url.Scheme && u.Opaque == s.url.Opaque && u.Path == s.url.Path && u.Host == s.url.Host {
+		return s.data, nil
+	}
+	if !s.external {
+		return nil, fmt.Errorf("cannot load %s: references to other documents require spec_file or spec_dir", u.String())
+	}
+	return s.cache.read(u)
+}
+
+// readJSON returns the document at a URL as JSON, converting YAML.
+func (s *specSource) readJSON(u *url.URL) ([]byte, error) {
+	data, err := s.read(u)
+	if err != nil {
+		return nil, err
+	}
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') {
+		return data, nil
+	}
+	return yaml.YAMLToJSON(data)
+}
+
+// Load implements jsonschema.URLLoader.
+func (s *specSource) Load(rawURL string) (any, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	data, err := s.readJSON(u)
+	if err != nil {
+		return nil, err
+	}
+	return jsonschema.UnmarshalJSON(bytes.NewReader(data))
+}
+
+// resolve resolves a reference found in the document at base.
+func (s *specSource) resolve(base *url.URL, ref string) (*url.URL, error) {
+	r, err := url.Parse(ref)
+	if err != nil {
+		return nil, err
+	}
+	return base.ResolveReference(r), nil
+}
+
+// pathLoader loads documents for go-openapi, which passes file paths or URLs.
+// Documents are converted by convert, if set.
+func (s *specSource) pathLoader(convert func([]byte) ([]byte, error)) func(string) (json.RawMessage, error) {
+	return func(p string) (json.RawMessage, error) {
+		u, err := url.Parse(p)
+		if err != nil || u.Scheme == "" || len(u.Scheme) == 1 {
+			// A path, or a Windows path with a drive letter.
+			u = fileURL(p)
+		}
+		u.Fragment = ""
+		data, err := s.readJSON(u)
+		if err != nil || convert == nil {
+			return data, err
+		}
+		return convert(data)
+	}
+}
+
+// checkRefCycles reports a $ref that leads back to itself through other
+// $refs without any schema in between, which no validator can resolve.
+func (s *specSource) checkRefCycles() error {
+	docs := make(map[string]any)
+	load := func(u *url.URL) (any, bool) {
+		key := *u
+		key.Fragment = ""
+		if doc, ok := docs[key.String()]; ok {
+			return doc, doc != nil
+		}
+		docs[key.String()] = nil
+		data, err := s.readJSON(&key)
+		if err != nil {
+			return nil, false
+		}
+		var doc any
+		if json.Unmarshal(data, &doc) != nil {
+			return nil, false
+		}
+		docs[key.String()] = doc
+		return doc, true
+	}
+
+	// target returns the node a resolved reference points to.
+	target := func(u *url.URL) (any, bool) {
+		doc, ok := load(u)
+		if !ok {
+			return nil, false
+		}
+		segments, err := parsePointer(u.Fragment)
+		if err != nil {
+			// An anchor, not a JSON Pointer.
+			return nil, false
+		}
+		return pointerValue(doc, segments)
+	}
+
+	// follow follows a chain of $refs starting at a reference.
+	follow := func(u *url.URL) error {
+		seen := make(map[string]bool)
+		var chain []string
+		for {
+			chain = append(chain, u.String())
+			if seen[u.String()] {
+				return fmt.Errorf("$ref cycle: %s", strings.Join(chain, " -> "))
+			}
+			seen[u.String()] = true
+
+			node, ok := target(u)
+			if !ok {
+				return nil
+			}
+			m, ok := node.(map[string]any)
+			if !ok {
+				return nil
+			}
+			ref, ok := m["$ref"].(string)
+			if !ok {
+				return nil
+			}
+			next, err := s.resolve(u, ref)
+			if err != nil {
+				return nil
+			}
+			u = next
+		}
+	}
+
+	queue := []*url.URL{s.url}
+	scanned := map[string]bool{s.url.String(): true}
+	for len(queue) > 0 {
+		base := queue[0]
+		queue = queue[1:]
+		doc, ok := load(base)
+		if !ok {
+			continue
+		}
+
+		var refs []string
+		var walk func(node any)
+		walk = func(node any) {
+			switch n := node.(type) {
+			case map[string]any:
+				if ref, ok := n["$ref"].(string); ok {
+					refs = append(refs, ref)
+				}
+				for _, e := range n {
+					walk(e)
+				}
+			case []any:
+				for _, e := range n {
+					walk(e)
+				}
+			}
+		}
+		walk(doc)
+
+		for _, ref := range refs {
+			r, err := url.Parse(ref)
+			if err != nil {
+				continue
+			}
+			if !s.external && r.Scheme == "" && r.Path != "" {
+				return fmt.Errorf("cannot resolve %q: relative references to other documents require spec_file or spec_dir", ref)
+			}
+			u := base.ResolveReference(r)
+			if err := follow(u); err != nil {
+				return err
+			}
+			doc := *u
+			doc.Fragment = ""
+			if !scanned[doc.String()] && (s.external || doc.String() == s.url.String()) {
+				scanned[doc.String()] = true
+				queue = append(queue, &doc)
+			}
+		}
+	}
+	return nil
+}
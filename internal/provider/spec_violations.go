@@ -0,0 +1,236 @@
+package provider
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// maxSpecViolations caps the violations reported for one item, so a badly
+// broken item does not bury the plan output.
+const maxSpecViolations = 20
+
+// maxViolationValueLength truncates the offending values shown in
+// diagnostics.
+const maxViolationValueLength = 80
+
+var violationPrinter = message.NewPrinter(language.English)
+
+// specViolation is one way an item breaks the spec.
+type specViolation struct {
+	// Pointer is the JSON Pointer of the offending value.
+	Pointer []string
+	// Keyword is the schema keyword that failed, e.g. "required".
+	Keyword string
+	// SchemaLocation is the location of the failed keyword in the spec.
+	SchemaLocation string
+	// Message states what the spec expected.
+	Message string
+}
+
+// jsonSchemaViolations flattens a jsonschema validation error into its
+// violations. The branches of a failed anyOf or oneOf are reported as part
+// of one violation, as only one of them has to be fixed.
+func jsonSchemaViolations(err error) []specViolation {
+	var verr *jsonschema.ValidationError
+	if !errors.As(err, &verr) {
+		return []specViolation{{Message: err.Error()}}
+	}
+
+	var violations []specViolation
+	var walk func(e *jsonschema.ValidationError)
+	walk = func(e *jsonschema.ValidationError) {
+		switch e.ErrorKind.(type) {
+		case *kind.AnyOf, *kind.OneOf:
+		default:
+			if len(e.Causes) > 0 {
+				for _, c := range e.Causes {
+					walk(c)
+				}
+				return
+			}
+		}
+
+		v := specViolation{
+			Pointer:        e.InstanceLocation,
+			SchemaLocation: e.SchemaURL,
+			Message:        e.ErrorKind.LocalizedString(violationPrinter),
+		}
+		if kw := e.ErrorKind.KeywordPath(); len(kw) > 0 {
+			v.Keyword = kw[0]
+			v.SchemaLocation = strings.TrimSuffix(e.SchemaURL, "/") + formatPointer(kw)
+		}
+		var branches []string
+		for _, c := range e.Causes {
+			for _, b := range jsonSchemaViolations(c) {
+				branches = append(branches, fmt.Sprintf("at '%s': %s", formatPointer(b.Pointer), b.Message))
+			}
+		}
+		if len(branches) > 0 {
+			v.Message += " (" + strings.Join(branches, "; ") + ")"
+		}
+		violations = append(violations, v)
+	}
+	walk(verr)
+	return violations
+}
+
+// openAPIViolations flattens an openapi3 validation error into its
+// violations.
+func openAPIViolations(err error, ref string) []specViolation {
+	var multi openapi3.MultiError
+	if errors.As(err, &multi) {
+		var violations []specViolation
+		for _, e := range multi {
+			violations = append(violations, openAPIViolations(e, ref)...)
+		}
+		return violations
+	}
+
+	var schemaErr *openapi3.SchemaError
+	if !errors.As(err, &schemaErr) {
+		return []specViolation{{Message: err.Error(), SchemaLocation: ref}}
+	}
+	if schemaErr.Origin != nil {
+		if violations := openAPIViolations(schemaErr.Origin, ref); len(violations) > 0 {
+			return violations
+		}
+	}
+	reason := schemaErr.Reason
+	if reason == "" {
+		reason = fmt.Sprintf("doesn't match schema %q", schemaErr.SchemaField)
+	}
+	return []specViolation{{
+		Pointer:        schemaErr.JSONPointer(),
+		Keyword:        schemaErr.SchemaField,
+		SchemaLocation: ref,
+		Message:        reason,
+	}}
+}
+
+// specViolationDiagnostics reports each violation as an error or warning
+// against the "json" attribute, as severity says, up to maxSpecViolations,
+// after a summary when there is more than one. Violations are reported in
+// the order of their JSON Pointers, then keywords and schema locations, as
+// validators visit properties in no particular order.
+func specViolationDiagnostics(item interface{}, violations []specViolation, severity *validationSeverity) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	violations = slices.Clone(violations)
+	slices.SortStableFunc(violations, compareSpecViolations)
+
+	type reported struct {
+		specViolation
+		severity string
+	}
+	var report []reported
+	hasErrors := false
+	for _, v := range violations {
+		if s := severity.of(v); s != validationModeOff {
+			report = append(report, reported{v, s})
+			hasErrors = hasErrors || s == validationModeError
+		}
+	}
+
+	add := func(severity, detail string) {
+		if severity == validationModeError {
+			diags.AddAttributeError(path.Root("json"), "JSON Spec Validation Failure", detail)
+		} else {
+			diags.AddAttributeWarning(path.Root("json"), "JSON Spec Validation Failure", detail)
+		}
+	}
+
+	if n := len(report); n > 1 {
+		summary := fmt.Sprintf("The item violates the spec in %d places.", n)
+		if n > maxSpecViolations {
+			summary += fmt.Sprintf(" Only the first %d are reported.", maxSpecViolations)
+		}
+		if hasErrors {
+			add(validationModeError, summary)
+		} else {
+			add(validationModeWarn, summary)
+		}
+	}
+
+	for i, v := range report {
+		if i == maxSpecViolations {
+			break
+		}
+		add(v.severity, v.detail(item))
+	}
+	return diags
+}
+
+// compareSpecViolations orders violations by JSON Pointer, then keyword, then
+// schema location.
+func compareSpecViolations(a, b specViolation) int {
+	if c := slices.Compare(a.Pointer, b.Pointer); c != 0 {
+		return c
+	}
+	if c := strings.Compare(a.Keyword, b.Keyword); c != 0 {
+		return c
+	}
+	return strings.Compare(a.SchemaLocation, b.SchemaLocation)
+}
+
+// detail describes the violation: where it is, what the spec expected, the
+// keyword that failed and the offending value.
+func (v specViolation) detail(item interface{}) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "at '%s': %s\n", formatPointer(v.Pointer), v.Message)
+	if v.Keyword != "" {
+		fmt.Fprintf(&sb, "\nKeyword: %s", v.Keyword)
+	}
+	if v.SchemaLocation != "" {
+		fmt.Fprintf(&sb, "\nSchema: %s", v.SchemaLocation)
+	}
+	if value, ok := pointerValue(item, v.Pointer); ok {
+		fmt.Fprintf(&sb, "\nValue: %s", violationValue(value))
+	}
+	return sb.String()
+}
+
+// pointerValue returns the value at a JSON Pointer within a decoded document.
+func pointerValue(doc interface{}, segments []string) (interface{}, bool) {
+	for _, s := range segments {
+		switch v := doc.(type) {
+		case map[string]interface{}:
+			var ok bool
+			if doc, ok = v[s]; !ok {
+				return nil, false
+			}
+		case []interface{}:
+			var i int
+			if _, err := fmt.Sscan(s, &i); err != nil || i < 0 || i >= len(v) {
+				return nil, false
+			}
+			doc = v[i]
+		default:
+			return nil, false
+		}
+	}
+	return doc, true
+}
+
+// violationValue renders a value as JSON, truncated to
+// maxViolationValueLength.
+func violationValue(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	if s := []rune(string(b)); len(s) > maxViolationValueLength {
+		return string(s[:maxViolationValueLength]) + "..."
+	}
+	return string(b)
+}
@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSpecViolationDiagnostics_order(t *testing.T) {
+	item := map[string]interface{}{"name": "x", "tags": []interface{}{"a"}}
+	violations := []specViolation{
+		{Pointer: []string{"tags", "0"}, Keyword: "minLength", SchemaLocation: "#/properties/tags/items/minLength"},
+		{Pointer: []string{"name"}, Keyword: "pattern", SchemaLocation: "#/properties/name/pattern"},
+		{Pointer: []string{"name"}, Keyword: "minLength", SchemaLocation: "#/allOf/1/properties/name/minLength"},
+		{Pointer: []string{"name"}, Keyword: "minLength", SchemaLocation: "#/allOf/0/properties/name/minLength"},
+		{Pointer: nil, Keyword: "required", SchemaLocation: "#/required"},
+	}
+	want := []string{
+		"#/required",
+		"#/allOf/0/properties/name/minLength",
+		"#/allOf/1/properties/name/minLength",
+		"#/properties/name/pattern",
+		"#/properties/tags/items/minLength",
+	}
+
+	diags := specViolationDiagnostics(item, violations, &validationSeverity{mode: validationModeError})
+	// The first diagnostic is the summary.
+	if len(diags) != len(want)+1 {
+		t.Fatalf("got %d diagnostics, want %d", len(diags), len(want)+1)
+	}
+	for i, location := range want {
+		if detail := diags[i+1].Detail(); !strings.Contains(detail, "\nSchema: "+location+"\n") {
+			t.Errorf("diagnostic %d is %q, want the violation at %s", i+1, detail, location)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// tableItemKey returns the key attributes of an item, which must be
+// strings, numbers or binary values.
+func tableItemKey(item map[string]types.AttributeValue, hashKey, rangeKey string) (map[string]types.AttributeValue, error) {
+	key := make(map[string]types.AttributeValue, 2)
+	for _, name := range []string{hashKey, rangeKey} {
+		if name == "" {
+			continue
+		}
+		v, ok := item[name]
+		if !ok {
+			return nil, fmt.Errorf("the item has no key attribute %q", name)
+		}
+		switch v.(type) {
+		case *types.AttributeValueMemberS, *types.AttributeValueMemberN, *types.AttributeValueMemberB:
+		default:
+			return nil, fmt.Errorf("key attribute %q must be a string, number or binary value, got %s", name, attributeValueType(v))
+		}
+		key[name] = v
+	}
+	return key, nil
+}
+
+// plainDecodeOptions decode items with sets sorted, so they can be compared
+// with plainJSONEqual.
+var plainDecodeOptions = decodeOptions{
+	SetFormat:    setFormatSorted,
+	BinaryFormat: binaryFormatBase64,
+}
+
+// plainItem decodes an item into plain JSON values.
+func plainItem(item map[string]types.AttributeValue) (interface{}, error) {
+	return attributeValueToInterface(&types.AttributeValueMemberM{Value: item}, plainDecodeOptions)
+}
+
+// plainJSON renders an item as plain JSON.
+func plainJSON(item map[string]types.AttributeValue) (string, error) {
+	return itemJSON(item, plainDecodeOptions)
+}
+
+// itemJSON renders an item as plain JSON, with sets and binary values
+// rendered as opts sets.
+func itemJSON(item map[string]types.AttributeValue, opts decodeOptions) (string, error) {
+	v, err := attributeValueToInterface(&types.AttributeValueMemberM{Value: item}, opts)
+	if err != nil {
+		return "", err
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// plainJSONEqual reports whether two decoded JSON values are equal, comparing
+// numbers by value, so 1.50 equals 1.5 as it does in DynamoDB.
+func plainJSONEqual(a, b interface{}) bool {
+	switch ua := a.(type) {
+	case map[string]interface{}:
+		ub, ok := b.(map[string]interface{})
+		if !ok || len(ua) != len(ub) {
+			return false
+		}
+		for k, v := range ua {
+			w, ok := ub[k]
+			if !ok || !plainJSONEqual(v, w) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		ub, ok := b.([]interface{})
+		if !ok || len(ua) != len(ub) {
+			return false
+		}
+		for i := range ua {
+			if !plainJSONEqual(ua[i], ub[i]) {
+				return false
+			}
+		}
+		return true
+	case json.Number:
+		ub, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		ra, okA := new(big.Rat).SetString(ua.String())
+		rb, okB := new(big.Rat).SetString(ub.String())
+		if !okA || !okB {
+			return ua == ub
+		}
+		return ra.Cmp(rb) == 0
+	default:
+		return a == b
+	}
+}
+
+// tableItemID identifies an item as its table name and its key in plain
+// JSON, e.g. orders/{"pk":"a","sk":1}. Table names cannot contain "/".
+func tableItemID(tableName string, key map[string]types.AttributeValue) (string, error) {
+	k, err := plainJSON(key)
+	if err != nil {
+		return "", err
+	}
+	return tableName + "/" + k, nil
+}
+
+// splitTableItemImportID splits an import ID into the endpoint it starts
+// with, if any, and the item ID. The endpoint is separated by "|", e.g.
+// http://localhost:8000|orders/{"pk":"a","sk":1}. Neither URLs nor table
+// names contain "{", so a "|" within the key is not taken for the separator.
+func splitTableItemImportID(id string) (string, string, error) {
+	endpoint, itemID, ok := strings.Cut(id, "|")
+	if !ok || strings.Contains(endpoint, "{") {
+		return "", id, nil
+	}
+	if endpoint == "" {
+		return "", "", fmt.Errorf("expected an endpoint before \"|\", e.g. http://localhost:8000|orders/{\"pk\":\"a\",\"sk\":1}, got: %q", id)
+	}
+	return endpoint, itemID, nil
+}
+
+// parseTableItemID splits an item ID into its table name and its key. Key
+// strings are read as S and numbers as N.
+func parseTableItemID(id string) (string, map[string]types.AttributeValue, error) {
+	tableName, k, ok := strings.Cut(id, "/")
+	if !ok || tableName == "" {
+		return "", nil, fmt.Errorf("expected <table_name>/<key JSON>, e.g. orders/{\"pk\":\"a\",\"sk\":1}, got: %q", id)
+	}
+	var v interface{}
+	if err := unmarshalJSONNumbers([]byte(k), &v); err != nil {
+		return "", nil, fmt.Errorf("invalid key JSON: %w", err)
+	}
+	key, err := (&attributeMarshaler{}).marshalMap(v)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid key JSON: %w", err)
+	}
+	return tableName, key, nil
+}
@@ -0,0 +1,204 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"slices"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	awstypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+const (
+	// batchGetItemLimit is the maximum number of keys in one BatchGetItem
+	// call.
+	batchGetItemLimit = 100
+	// batchMaxAttempts is the number of times a batch is sent before the
+	// items DynamoDB left unprocessed are reported as an error.
+	batchMaxAttempts = 10
+	// batchBackoff is the wait before the first retry of the unprocessed
+	// items of a batch. It doubles with every attempt.
+	batchBackoff = 100 * time.Millisecond
+	// batchMaxBackoff caps the wait between two attempts of a batch.
+	batchMaxBackoff = 5 * time.Second
+)
+
+// itemHash returns a SHA-256 hash of the content of an item. Items hash
+// equal when DynamoDB stores them equal: numbers are compared by value and
+// the members of sets in any order.
+func itemHash(item map[string]awstypes.AttributeValue) (string, error) {
+	canonical, err := canonicalAttributeValue(&awstypes.AttributeValueMemberM{Value: item})
+	if err != nil {
+		return "", err
+	}
+	b, err := SerializeAttributeMap(canonical.(*awstypes.AttributeValueMemberM).Value)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// canonicalAttributeValue rewrites numbers as exact fractions and sorts the
+// members of sets, so equal values serialize equal.
+func canonicalAttributeValue(v awstypes.AttributeValue) (awstypes.AttributeValue, error) {
+	switch v := v.(type) {
+	case *awstypes.AttributeValueMemberN:
+		n, err := canonicalNumber(v.Value)
+		return &awstypes.AttributeValueMemberN{Value: n}, err
+	case *awstypes.AttributeValueMemberNS:
+		members := make([]string, 0, len(v.Value))
+		for _, m := range v.Value {
+			n, err := canonicalNumber(m)
+			if err != nil {
+				return nil, err
+			}
+			members = append(members, n)
+		}
+		slices.Sort(members)
+		return &awstypes.AttributeValueMemberNS{Value: members}, nil
+	case *awstypes.AttributeValueMemberSS:
+		return &awstypes.AttributeValueMemberSS{Value: slices.Sorted(slices.Values(v.Value))}, nil
+	case *awstypes.AttributeValueMemberBS:
+		return &awstypes.AttributeValueMemberBS{Value: slices.SortedFunc(slices.Values(v.Value), bytes.Compare)}, nil
+	case *awstypes.AttributeValueMemberM:
+		m := make(map[string]awstypes.AttributeValue, len(v.Value))
+		for k, e := range v.Value {
+			c, err := canonicalAttributeValue(e)
+			if err != nil {
+				return nil, err
+			}
+			m[k] = c
+		}
+		return &awstypes.AttributeValueMemberM{Value: m}, nil
+	case *awstypes.AttributeValueMemberL:
+		l := make([]awstypes.AttributeValue, 0, len(v.Value))
+		for _, e := range v.Value {
+			c, err := canonicalAttributeValue(e)
+			if err != nil {
+				return nil, err
+			}
+			l = append(l, c)
+		}
+		return &awstypes.AttributeValueMemberL{Value: l}, nil
+	default:
+		return v, nil
+	}
+}
+
+// canonicalNumber renders a number as an exact fraction, e.g. 1.50 as 3/2.
+func canonicalNumber(s string) (string, error) {
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return "", fmt.Errorf("invalid number %q", s)
+	}
+	return r.RatString(), nil
+}
+
+// itemKeyID returns the ID of an item key: the key in plain JSON, with its
+// numbers in canonical decimal form, so keys DynamoDB stores as one item,
+// such as {"pk":1} and {"pk":1.0}, have the same ID.
+func itemKeyID(key map[string]awstypes.AttributeValue) (string, error) {
+	canonical := make(map[string]awstypes.AttributeValue, len(key))
+	for name, v := range key {
+		if n, ok := v.(*awstypes.AttributeValueMemberN); ok {
+			d, err := canonicalDecimal(n.Value)
+			if err != nil {
+				return "", err
+			}
+			v = &awstypes.AttributeValueMemberN{Value: d}
+		}
+		canonical[name] = v
+	}
+	return plainJSON(canonical)
+}
+
+// canonicalDecimal renders a number in plain decimal notation without
+// trailing zeros, e.g. 1.50 as 1.5 and 1E+2 as 100.
+func canonicalDecimal(s string) (string, error) {
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return "", fmt.Errorf("invalid number %q", s)
+	}
+	// DynamoDB numbers are decimals, so some power of ten up to the
+	// smallest magnitude DynamoDB stores is a multiple of the denominator.
+	scale := new(big.Int).SetInt64(1)
+	ten := big.NewInt(10)
+	for digits := 0; digits <= -dynamoDBNumberMinExponent+dynamoDBNumberPrecision; digits++ {
+		if new(big.Int).Mod(scale, r.Denom()).Sign() == 0 {
+			return r.FloatString(digits), nil
+		}
+		scale.Mul(scale, ten)
+	}
+	return "", fmt.Errorf("invalid number %q", s)
+}
+
+// batchWriteItems applies write requests to a table with BatchWriteItem, in
+// chunks of the API limit. Items DynamoDB leaves unprocessed, e.g. when the
+// table is throttled, are sent again with exponential backoff.
+func batchWriteItems(ctx context.Context, client *dynamodb.Client, tableName string, requests []awstypes.WriteRequest) error {
+	for chunk := range slices.Chunk(requests, batchWriteItemLimit) {
+		pending := map[string][]awstypes.WriteRequest{tableName: chunk}
+		for attempt := 0; len(pending) > 0; attempt++ {
+			if attempt == batchMaxAttempts {
+				return fmt.Errorf("%d write requests were still unprocessed after %d attempts", len(pending[tableName]), batchMaxAttempts)
+			}
+			if err := batchRetryWait(ctx, attempt); err != nil {
+				return err
+			}
+			out, err := client.BatchWriteItem(ctx, &dynamodb.BatchWriteItemInput{RequestItems: pending})
+			if err != nil {
+				return err
+			}
+			pending = out.UnprocessedItems
+		}
+	}
+	return nil
+}
+
+// batchGetItems reads the items with the given keys from a table with
+// strongly consistent BatchGetItem reads, in chunks of the API limit. Keys
+// without an item are left out of the result, which is in no particular
+// order.
+func batchGetItems(ctx context.Context, client *dynamodb.Client, tableName string, keys []map[string]awstypes.AttributeValue) ([]map[string]awstypes.AttributeValue, error) {
+	var items []map[string]awstypes.AttributeValue
+	for chunk := range slices.Chunk(keys, batchGetItemLimit) {
+		pending := map[string]awstypes.KeysAndAttributes{tableName: {Keys: chunk, ConsistentRead: aws.Bool(true)}}
+		for attempt := 0; len(pending) > 0; attempt++ {
+			if attempt == batchMaxAttempts {
+				return nil, fmt.Errorf("%d keys were still unprocessed after %d attempts", len(pending[tableName].Keys), batchMaxAttempts)
+			}
+			if err := batchRetryWait(ctx, attempt); err != nil {
+				return nil, err
+			}
+			out, err := client.BatchGetItem(ctx, &dynamodb.BatchGetItemInput{RequestItems: pending})
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, out.Responses[tableName]...)
+			pending = out.UnprocessedKeys
+		}
+	}
+	return items, nil
+}
+
+// batchRetryWait waits before an attempt of a batch: not at all before the
+// first, then batchBackoff, doubling up to batchMaxBackoff.
+func batchRetryWait(ctx context.Context, attempt int) error {
+	if attempt == 0 {
+		return nil
+	}
+	wait := min(batchBackoff<<(attempt-1), batchMaxBackoff)
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(wait):
+		return nil
+	}
+}
@@ -0,0 +1,300 @@
+package provider
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+// Vendor extensions that declare the keys and TTL attribute of a table on
+// the properties of its item schema.
+const (
+	// tableKeyExtension makes a property the hash or range key of the table.
+	tableKeyExtension = "x-dynamodb-key"
+	// tableGSIExtension makes a property a key of global secondary indexes.
+	tableGSIExtension = "x-dynamodb-gsi"
+	// tableTTLExtension makes a property the TTL attribute of the table.
+	tableTTLExtension = "x-dynamodb-ttl"
+)
+
+// Roles of a key attribute.
+const (
+	keyRoleHash  = "hash"
+	keyRoleRange = "range"
+)
+
+// Projection types of a global secondary index.
+const (
+	projectionTypeAll      = "ALL"
+	projectionTypeKeysOnly = "KEYS_ONLY"
+	projectionTypeInclude  = "INCLUDE"
+)
+
+var projectionTypes = []string{projectionTypeAll, projectionTypeKeysOnly, projectionTypeInclude}
+
+// tableSchema is the table definition declared by the vendor extensions of
+// an item schema.
+type tableSchema struct {
+	HashKey  string
+	RangeKey string
+	// Attributes maps each key attribute of the table and its indexes to its
+	// type: S, N or B.
+	Attributes map[string]string
+	// Indexes are the global secondary indexes, sorted by name.
+	Indexes      []*tableIndex
+	TTLAttribute string
+}
+
+// tableIndex is a global secondary index.
+type tableIndex struct {
+	Name             string
+	HashKey          string
+	RangeKey         string
+	ProjectionType   string
+	NonKeyAttributes []string
+}
+
+// gsiKey is one entry of x-dynamodb-gsi.
+type gsiKey struct {
+	Name             string
+	Key              string
+	ProjectionType   string
+	NonKeyAttributes []string
+}
+
+// tableSchemaFromSpec reads the table definition from the top-level
+// properties of an item schema, including those of its allOf:
+//
+//   - x-dynamodb-key: hash or range makes a property a key of the table
+//   - x-dynamodb-gsi makes a property a key of global secondary indexes. It
+//     is the index name, for its hash key, an object with name, key (hash,
+//     the default, or range), projection_type (ALL, the default, KEYS_ONLY
+//     or INCLUDE) and non_key_attributes, or a list of either
+//   - x-dynamodb-ttl: true makes a property the TTL attribute
+//
+// Key types come from x-dynamodb-type, or from the JSON type and format of
+// the property, as schema_types reads them.
+func tableSchemaFromSpec(s *spec.Schema) (*tableSchema, error) {
+	t := &tableSchema{Attributes: make(map[string]string)}
+	indexes := make(map[string]*tableIndex)
+
+	properties := topLevelProperties(s)
+	for _, name := range slices.Sorted(maps.Keys(properties)) {
+		p := properties[name]
+
+		if role, ok := p.Extensions.GetString(tableKeyExtension); ok {
+			if err := t.setKey(&t.HashKey, &t.RangeKey, "table", name, role); err != nil {
+				return nil, err
+			}
+			if err := t.addAttribute(name, &p); err != nil {
+				return nil, err
+			}
+		} else if v, ok := p.Extensions[tableKeyExtension]; ok {
+			return nil, fmt.Errorf("property %q: %s must be %q or %q, got: %v", name, tableKeyExtension, keyRoleHash, keyRoleRange, v)
+		}
+
+		if v, ok := p.Extensions[tableGSIExtension]; ok {
+			keys, err := parseGSIKeys(v)
+			if err != nil {
+				return nil, fmt.Errorf("property %q: %s %w", name, tableGSIExtension, err)
+			}
+			for _, k := range keys {
+				index, ok := indexes[k.Name]
+				if !ok {
+					index = &tableIndex{Name: k.Name}
+					indexes[k.Name] = index
+				}
+				if err := t.setKey(&index.HashKey, &index.RangeKey, fmt.Sprintf("index %q", k.Name), name, k.Key); err != nil {
+					return nil, err
+				}
+				if err := index.setProjection(k); err != nil {
+					return nil, err
+				}
+			}
+			if err := t.addAttribute(name, &p); err != nil {
+				return nil, err
+			}
+		}
+
+		if v, ok := p.Extensions[tableTTLExtension]; ok {
+			if v != true {
+				if v == false {
+					continue
+				}
+				return nil, fmt.Errorf("property %q: %s must be true or false, got: %v", name, tableTTLExtension, v)
+			}
+			if t.TTLAttribute != "" {
+				return nil, fmt.Errorf("both %q and %q are declared as the TTL attribute", t.TTLAttribute, name)
+			}
+			if typ, err := keyAttributeType(&p); err != nil || typ != attributeTypeN {
+				return nil, fmt.Errorf("TTL attribute %q must be a number of epoch seconds", name)
+			}
+			t.TTLAttribute = name
+		}
+	}
+
+	if t.HashKey == "" {
+		return nil, fmt.Errorf("no property is declared as the hash key of the table with %s: %s", tableKeyExtension, keyRoleHash)
+	}
+	for _, name := range slices.Sorted(maps.Keys(indexes)) {
+		index := indexes[name]
+		if index.HashKey == "" {
+			return nil, fmt.Errorf("index %q has no hash key", name)
+		}
+		if index.ProjectionType == "" {
+			index.ProjectionType = projectionTypeAll
+		}
+		t.Indexes = append(t.Indexes, index)
+	}
+	return t, nil
+}
+
+// topLevelProperties returns the properties of a schema and of its allOf.
+func topLevelProperties(s *spec.Schema) map[string]spec.Schema {
+	properties := make(map[string]spec.Schema)
+	for i := range s.AllOf {
+		maps.Copy(properties, topLevelProperties(&s.AllOf[i]))
+	}
+	maps.Copy(properties, s.Properties)
+	return properties
+}
+
+// setKey records a property as the hash or range key of the table or an
+// index.
+func (t *tableSchema) setKey(hashKey, rangeKey *string, owner, name, role string) error {
+	key := hashKey
+	switch role {
+	case keyRoleHash:
+	case keyRoleRange:
+		key = rangeKey
+	default:
+		return fmt.Errorf("property %q: key must be %q or %q, got: %q", name, keyRoleHash, keyRoleRange, role)
+	}
+	if *key != "" {
+		return fmt.Errorf("both %q and %q are declared as the %s key of the %s", *key, name, role, owner)
+	}
+	*key = name
+	return nil
+}
+
+// addAttribute records the type of a key attribute.
+func (t *tableSchema) addAttribute(name string, p *spec.Schema) error {
+	typ, err := keyAttributeType(p)
+	if err != nil {
+		return fmt.Errorf("key attribute %q: %w", name, err)
+	}
+	t.Attributes[name] = typ
+	return nil
+}
+
+// setProjection records the projection an entry of x-dynamodb-gsi declares.
+// Entries for the same index must not disagree.
+func (index *tableIndex) setProjection(k gsiKey) error {
+	if k.ProjectionType == "" {
+		return nil
+	}
+	if index.ProjectionType != "" && (index.ProjectionType != k.ProjectionType || !slices.Equal(index.NonKeyAttributes, k.NonKeyAttributes)) {
+		return fmt.Errorf("index %q is declared with different projections", index.Name)
+	}
+	index.ProjectionType = k.ProjectionType
+	index.NonKeyAttributes = k.NonKeyAttributes
+	return nil
+}
+
+// keyAttributeType returns the DynamoDB type of a key attribute.
+func keyAttributeType(p *spec.Schema) (string, error) {
+	if ext, ok := p.Extensions.GetString(schemaTypeExtension); ok {
+		ext = strings.ToUpper(ext)
+		if !slices.Contains(keyTypes, ext) {
+			return "", fmt.Errorf("%s %q is not a key type, expected one of %s", schemaTypeExtension, ext, strings.Join(keyTypes, ", "))
+		}
+		return ext, nil
+	}
+	if len(p.Type) == 1 {
+		switch {
+		case p.Type.Contains("string") && isBinaryFormat(p.Format):
+			return attributeTypeB, nil
+		case p.Type.Contains("string"):
+			return attributeTypeS, nil
+		case p.Type.Contains("integer"), p.Type.Contains("number"):
+			return attributeTypeN, nil
+		}
+	}
+	got := strings.Join(p.Type, ", ")
+	if got == "" {
+		got = "no type"
+	}
+	return "", fmt.Errorf("expected type string, integer or number, or %s, got: %s", schemaTypeExtension, got)
+}
+
+// parseGSIKeys reads an x-dynamodb-gsi value.
+func parseGSIKeys(v interface{}) ([]gsiKey, error) {
+	switch uv := v.(type) {
+	case string:
+		if uv == "" {
+			return nil, fmt.Errorf("index name cannot be empty")
+		}
+		return []gsiKey{{Name: uv, Key: keyRoleHash}}, nil
+
+	case map[string]interface{}:
+		k := gsiKey{Key: keyRoleHash}
+		for field, fv := range uv {
+			s, isString := fv.(string)
+			switch field {
+			case "name":
+				k.Name = s
+			case "key":
+				k.Key = s
+			case "projection_type":
+				k.ProjectionType = s
+				if !slices.Contains(projectionTypes, s) {
+					return nil, fmt.Errorf("projection_type must be one of %s, got: %v", strings.Join(projectionTypes, ", "), fv)
+				}
+			case "non_key_attributes":
+				l, ok := fv.([]interface{})
+				if !ok {
+					return nil, fmt.Errorf("non_key_attributes must be a list of attribute names")
+				}
+				for _, a := range l {
+					a, ok := a.(string)
+					if !ok {
+						return nil, fmt.Errorf("non_key_attributes must be a list of attribute names")
+					}
+					k.NonKeyAttributes = append(k.NonKeyAttributes, a)
+				}
+				slices.Sort(k.NonKeyAttributes)
+				continue
+			default:
+				return nil, fmt.Errorf("has unknown field %q, expected name, key, projection_type or non_key_attributes", field)
+			}
+			if !isString {
+				return nil, fmt.Errorf("%s must be a string, got: %v", field, fv)
+			}
+		}
+		if k.Name == "" {
+			return nil, fmt.Errorf("must name the index")
+		}
+		if len(k.NonKeyAttributes) > 0 && k.ProjectionType != projectionTypeInclude {
+			return nil, fmt.Errorf("non_key_attributes require projection_type %s", projectionTypeInclude)
+		}
+		return []gsiKey{k}, nil
+
+	case []interface{}:
+		var keys []gsiKey
+		for _, e := range uv {
+			if _, ok := e.([]interface{}); ok {
+				return nil, fmt.Errorf("lists cannot be nested")
+			}
+			k, err := parseGSIKeys(e)
+			if err != nil {
+				return nil, err
+			}
+			keys = append(keys, k...)
+		}
+		return keys, nil
+	}
+	return nil, fmt.Errorf("must be an index name, an object or a list of either, got: %v", v)
+}
@@ -0,0 +1,287 @@
+package provider
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// DynamoDB attribute value type descriptors.
+const (
+	attributeTypeS    = "S"
+	attributeTypeN    = "N"
+	attributeTypeB    = "B"
+	attributeTypeSS   = "SS"
+	attributeTypeNS   = "NS"
+	attributeTypeBS   = "BS"
+	attributeTypeNULL = "NULL"
+)
+
+// typeHintTypes are the DynamoDB types a type hint may target.
+var typeHintTypes = []string{
+	attributeTypeSS,
+	attributeTypeNS,
+	attributeTypeBS,
+	attributeTypeB,
+	attributeTypeN,
+	attributeTypeS,
+	attributeTypeNULL,
+}
+
+// typeHint forces the values matching a JSON Pointer pattern to a DynamoDB
+// type. A "*" segment in the pattern matches any single key or index.
+type typeHint struct {
+	Pattern string
+	Type    string
+	// Source names where the hint came from in error messages.
+	Source string
+	// FromDateTime converts an RFC 3339 date-time string into epoch seconds
+	// when Type is N.
+	FromDateTime bool
+
+	segments []string
+}
+
+// parseTypeHints parses a map of JSON Pointer patterns to DynamoDB types,
+// returned in pattern order so matching is deterministic.
+func parseTypeHints(hints map[string]string) ([]typeHint, error) {
+	patterns := make([]string, 0, len(hints))
+	for p := range hints {
+		patterns = append(patterns, p)
+	}
+	sort.Strings(patterns)
+
+	parsed := make([]typeHint, 0, len(hints))
+	for _, p := range patterns {
+		t := strings.ToUpper(hints[p])
+		if !slices.Contains(typeHintTypes, t) {
+			return nil, fmt.Errorf("type hint %q has unsupported type %q, expected one of %s", p, hints[p], strings.Join(typeHintTypes, ", "))
+		}
+		segments, err := parsePointer(p)
+		if err != nil {
+			return nil, fmt.Errorf("type hint %q: %w", p, err)
+		}
+		if len(segments) == 0 {
+			return nil, fmt.Errorf("type hint %q: the top-level item is always a map", p)
+		}
+		parsed = append(parsed, typeHint{Pattern: p, Type: t, Source: fmt.Sprintf("type hint %q", p), segments: segments})
+	}
+	return parsed, nil
+}
+
+// matches reports whether the hint applies to the value at the given path.
+func (h typeHint) matches(segments []string) bool {
+	if len(h.segments) != len(segments) {
+		return false
+	}
+	for i, s := range h.segments {
+		if s != "*" && s != segments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// matchTypeHint returns the type hint for the value at the given path, if any.
+// Several hints may match as long as they agree on the type.
+func matchTypeHint(hints []typeHint, segments []string) (*typeHint, error) {
+	var match *typeHint
+	for i := range hints {
+		if !hints[i].matches(segments) {
+			continue
+		}
+		if match != nil && match.Type != hints[i].Type {
+			return nil, fmt.Errorf("type hints %q (%s) and %q (%s) conflict", match.Pattern, match.Type, hints[i].Pattern, hints[i].Type)
+		}
+		if match == nil {
+			match = &hints[i]
+		}
+	}
+	return match, nil
+}
+
+var (
+	pointerEscaper   = strings.NewReplacer("~", "~0", "/", "~1")
+	pointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+)
+
+// parsePointer splits a JSON Pointer (RFC 6901) into its unescaped segments.
+func parsePointer(p string) ([]string, error) {
+	if p == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(p, "/") {
+		return nil, fmt.Errorf("JSON Pointer must start with \"/\"")
+	}
+	segments := strings.Split(p[1:], "/")
+	for i, s := range segments {
+		segments[i] = pointerUnescaper.Replace(s)
+	}
+	return segments, nil
+}
+
+// formatPointer joins path segments into an escaped JSON Pointer.
+func formatPointer(segments []string) string {
+	var sb strings.Builder
+	for _, s := range segments {
+		sb.WriteByte('/')
+		sb.WriteString(pointerEscaper.Replace(s))
+	}
+	return sb.String()
+}
+
+// hintedAttributeValue converts a decoded JSON value into the DynamoDB type
+// named by a type hint, checking the value can be represented as that type.
+func hintedAttributeValue(v interface{}, hint typeHint) (types.AttributeValue, error) {
+	t := hint.Type
+	switch t {
+	case attributeTypeS:
+		switch uv := v.(type) {
+		case string:
+			return &types.AttributeValueMemberS{Value: uv}, nil
+		case json.Number:
+			return &types.AttributeValueMemberS{Value: uv.String()}, nil
+		case bool:
+			return &types.AttributeValueMemberS{Value: fmt.Sprint(uv)}, nil
+		}
+
+	case attributeTypeN:
+		if s, ok := v.(string); ok && hint.FromDateTime {
+			n, err := epochSeconds(s)
+			if err != nil {
+				return nil, err
+			}
+			return &types.AttributeValueMemberN{Value: n}, nil
+		}
+		if n, ok := hintedNumber(v); ok {
+			if err := validateDynamoDBNumber(n); err != nil {
+				return nil, err
+			}
+			return &types.AttributeValueMemberN{Value: n}, nil
+		}
+		if s, ok := v.(string); ok {
+			return nil, fmt.Errorf("string %q is not a valid number", s)
+		}
+
+	case attributeTypeB:
+		if s, ok := v.(string); ok {
+			b, err := base64.StdEncoding.DecodeString(s)
+			if err != nil {
+				return nil, fmt.Errorf("string is not valid base64: %w", err)
+			}
+			return &types.AttributeValueMemberB{Value: b}, nil
+		}
+
+	case attributeTypeNULL:
+		if v == nil || v == "" {
+			return &types.AttributeValueMemberNULL{Value: true}, nil
+		}
+
+	case attributeTypeSS, attributeTypeNS, attributeTypeBS:
+		l, ok := v.([]interface{})
+		if !ok {
+			break
+		}
+		if len(l) == 0 {
+			return nil, fmt.Errorf("%s sets cannot be empty", t)
+		}
+		return hintedSet(l, t)
+	}
+
+	return nil, fmt.Errorf("cannot convert JSON %s to DynamoDB type %s", jsonTypeName(v), t)
+}
+
+// epochSeconds converts an RFC 3339 date-time into seconds since the Unix
+// epoch, keeping any fractional seconds.
+func epochSeconds(s string) (string, error) {
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return "", fmt.Errorf("string %q is not an RFC 3339 date-time", s)
+	}
+	if t.Nanosecond() == 0 {
+		return strconv.FormatInt(t.Unix(), 10), nil
+	}
+	r := new(big.Rat).SetFrac64(int64(t.Nanosecond()), int64(time.Second))
+	r.Add(r, new(big.Rat).SetInt64(t.Unix()))
+	return strings.TrimRight(r.FloatString(9), "0"), nil
+}
+
+// hintedNumber returns the number literal of a JSON number or numeric string.
+func hintedNumber(v interface{}) (string, bool) {
+	switch uv := v.(type) {
+	case json.Number:
+		return uv.String(), true
+	case string:
+		return uv, jsonNumberPattern.MatchString(uv)
+	}
+	return "", false
+}
+
+func hintedSet(l []interface{}, t string) (types.AttributeValue, error) {
+	switch t {
+	case attributeTypeSS:
+		members := make([]string, 0, len(l))
+		seen := make(map[string]int, len(l))
+		for i, e := range l {
+			s, ok := e.(string)
+			if !ok {
+				return nil, fmt.Errorf("[%d]: SS members must be strings, got %s", i, jsonTypeName(e))
+			}
+			if j, dup := seen[s]; dup {
+				return nil, fmt.Errorf("[%d]: duplicate SS member %q, also at [%d]", i, s, j)
+			}
+			seen[s] = i
+			members = append(members, s)
+		}
+		return &types.AttributeValueMemberSS{Value: members}, nil
+
+	case attributeTypeNS:
+		members := make([]string, 0, len(l))
+		seen := make(map[string]int, len(l))
+		for i, e := range l {
+			n, ok := hintedNumber(e)
+			if !ok {
+				return nil, fmt.Errorf("[%d]: NS members must be numbers or numeric strings, got %s", i, jsonTypeName(e))
+			}
+			if err := validateDynamoDBNumber(n); err != nil {
+				return nil, fmt.Errorf("[%d]: %w", i, err)
+			}
+			// Compare by value, so 1 and 1.0 are the same member.
+			r, _ := new(big.Rat).SetString(n)
+			if j, dup := seen[r.RatString()]; dup {
+				return nil, fmt.Errorf("[%d]: duplicate NS member %s, also at [%d]", i, n, j)
+			}
+			seen[r.RatString()] = i
+			members = append(members, n)
+		}
+		return &types.AttributeValueMemberNS{Value: members}, nil
+
+	default:
+		members := make([][]byte, 0, len(l))
+		seen := make(map[string]int, len(l))
+		for i, e := range l {
+			s, ok := e.(string)
+			if !ok {
+				return nil, fmt.Errorf("[%d]: BS members must be base64 strings, got %s", i, jsonTypeName(e))
+			}
+			b, err := base64.StdEncoding.DecodeString(s)
+			if err != nil {
+				return nil, fmt.Errorf("[%d]: string is not valid base64: %w", i, err)
+			}
+			if j, dup := seen[string(b)]; dup {
+				return nil, fmt.Errorf("[%d]: duplicate BS member, also at [%d]", i, j)
+			}
+			seen[string(b)] = i
+			members = append(members, b)
+		}
+		return &types.AttributeValueMemberBS{Value: members}, nil
+	}
+}
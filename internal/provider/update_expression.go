@@ -0,0 +1,201 @@
+package provider
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// updateExpression builds an UpdateItem update expression. Every attribute
+// name is aliased as #nN, so reserved words and special characters never need
+// escaping, and every value is a :vN placeholder.
+type updateExpression struct {
+	names  map[string]string
+	values map[string]types.AttributeValue
+
+	// aliases maps attribute names to their #nN placeholder, so a name used
+	// in several paths is aliased once.
+	aliases map[string]string
+	// paths holds the segments of every path the expression touches, as
+	// DynamoDB rejects overlapping document paths.
+	paths [][]pathSegment
+
+	set, remove, add []string
+}
+
+// pathSegment is an attribute name, or a list index, of a document path.
+type pathSegment struct {
+	name  string
+	index bool
+}
+
+// listIndexPattern matches the attribute path segments that are list
+// indexes.
+var listIndexPattern = regexp.MustCompile(`^\[(0|[1-9][0-9]*)\]$`)
+
+// parseAttributePath parses an attribute path into document path segments.
+// Attribute paths are written like JSON Pointers, "/"-separated with "~1" and
+// "~0" escaping "/" and "~", but segments written as "[N]" are list indexes
+// and every other segment, numeric or not, is an attribute name, so the keys
+// of maps such as {"2024": 1} are addressed as names.
+func parseAttributePath(p string) ([]pathSegment, error) {
+	if !strings.HasPrefix(p, "/") {
+		return nil, fmt.Errorf("attribute path %q must start with \"/\"", p)
+	}
+	segments, err := parsePointer(p)
+	if err != nil {
+		return nil, err
+	}
+	path := make([]pathSegment, 0, len(segments))
+	for i, s := range segments {
+		m := listIndexPattern.FindStringSubmatch(s)
+		if m == nil {
+			path = append(path, pathSegment{name: s})
+			continue
+		}
+		if i == 0 {
+			return nil, fmt.Errorf("the top-level attribute of %s cannot be a list index", p)
+		}
+		if _, err := strconv.ParseUint(m[1], 10, 32); err != nil {
+			return nil, fmt.Errorf("list index %s of %s is out of range", s, p)
+		}
+		path = append(path, pathSegment{name: m[1], index: true})
+	}
+	return path, nil
+}
+
+// formatAttributePath renders path segments as an attribute path, with list
+// indexes in brackets.
+func formatAttributePath(segments []pathSegment) string {
+	var sb strings.Builder
+	for _, s := range segments {
+		sb.WriteByte('/')
+		if s.index {
+			fmt.Fprintf(&sb, "[%s]", s.name)
+			continue
+		}
+		sb.WriteString(pointerEscaper.Replace(s.name))
+	}
+	return sb.String()
+}
+
+func newUpdateExpression() *updateExpression {
+	return &updateExpression{
+		names:   make(map[string]string),
+		values:  make(map[string]types.AttributeValue),
+		aliases: make(map[string]string),
+	}
+}
+
+// Set adds "path = value" to the SET clause. With mergeMaps, map values are
+// set attribute by attribute so the other attributes of an existing map are
+// kept; empty maps are still set whole.
+func (u *updateExpression) Set(segments []pathSegment, v types.AttributeValue, mergeMaps bool) error {
+	if m, ok := v.(*types.AttributeValueMemberM); ok && mergeMaps && len(m.Value) > 0 {
+		for _, k := range slices.Sorted(maps.Keys(m.Value)) {
+			if err := u.Set(append(slices.Clip(segments), pathSegment{name: k}), m.Value[k], mergeMaps); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	p, err := u.path(segments)
+	if err != nil {
+		return err
+	}
+	u.set = append(u.set, fmt.Sprintf("%s = %s", p, u.value(v)))
+	return nil
+}
+
+// Remove adds the path to the REMOVE clause.
+func (u *updateExpression) Remove(segments []pathSegment) error {
+	p, err := u.path(segments)
+	if err != nil {
+		return err
+	}
+	u.remove = append(u.remove, p)
+	return nil
+}
+
+// Add adds "path value" to the ADD clause, which adds a number to the
+// attribute, or sets it when the attribute does not exist.
+func (u *updateExpression) Add(segments []pathSegment, n string) error {
+	if err := validateDynamoDBNumber(n); err != nil {
+		return err
+	}
+	p, err := u.path(segments)
+	if err != nil {
+		return err
+	}
+	u.add = append(u.add, fmt.Sprintf("%s %s", p, u.value(&types.AttributeValueMemberN{Value: n})))
+	return nil
+}
+
+// String returns the update expression.
+func (u *updateExpression) String() string {
+	var clauses []string
+	if len(u.set) > 0 {
+		clauses = append(clauses, "SET "+strings.Join(u.set, ", "))
+	}
+	if len(u.remove) > 0 {
+		clauses = append(clauses, "REMOVE "+strings.Join(u.remove, ", "))
+	}
+	if len(u.add) > 0 {
+		clauses = append(clauses, "ADD "+strings.Join(u.add, ", "))
+	}
+	return strings.Join(clauses, " ")
+}
+
+// path renders a document path, aliasing each attribute name.
+func (u *updateExpression) path(segments []pathSegment) (string, error) {
+	if len(segments) == 0 {
+		return "", fmt.Errorf("the path cannot be the whole item")
+	}
+	for _, other := range u.paths {
+		if pathsOverlap(segments, other) {
+			return "", fmt.Errorf("path %s overlaps path %s", formatAttributePath(segments), formatAttributePath(other))
+		}
+	}
+	u.paths = append(u.paths, segments)
+
+	var sb strings.Builder
+	for i, s := range segments {
+		if s.index {
+			fmt.Fprintf(&sb, "[%s]", s.name)
+			continue
+		}
+		if i > 0 {
+			sb.WriteByte('.')
+		}
+		sb.WriteString(u.alias(s.name))
+	}
+	return sb.String(), nil
+}
+
+func (u *updateExpression) alias(name string) string {
+	if a, ok := u.aliases[name]; ok {
+		return a
+	}
+	a := fmt.Sprintf("#n%d", len(u.aliases))
+	u.aliases[name] = a
+	u.names[a] = name
+	return a
+}
+
+func (u *updateExpression) value(v types.AttributeValue) string {
+	p := fmt.Sprintf(":v%d", len(u.values))
+	u.values[p] = v
+	return p
+}
+
+// pathsOverlap reports whether one path is the other or one of its parents.
+func pathsOverlap(a, b []pathSegment) bool {
+	n := min(len(a), len(b))
+	return slices.Equal(a[:n], b[:n])
+}
@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// How spec violations are reported.
+const (
+	validationModeError = "error"
+	validationModeWarn  = "warn"
+	validationModeOff   = "off"
+)
+
+// validationModes are the severities validation_severity accepts.
+var validationModes = []string{validationModeError, validationModeWarn, validationModeOff}
+
+// validationSeverity decides how each spec violation is reported: as the
+// override for the most specific path it is under says, else as the override
+// for its keyword says, else as the validation mode says.
+type validationSeverity struct {
+	mode     string
+	keywords map[string]string
+	paths    []severityPath
+}
+
+// severityPath overrides the severity of violations at or below the values
+// matching a JSON Pointer pattern.
+type severityPath struct {
+	segments []string
+	severity string
+}
+
+// parseValidationSeverity parses the severity overrides of a validation
+// mode. Keys starting with "/" are JSON Pointer patterns, where "*" matches
+// any single key or index; other keys are schema keywords.
+func parseValidationSeverity(mode string, overrides map[string]string) (*validationSeverity, error) {
+	if mode == "" {
+		mode = validationModeError
+	}
+
+	s := &validationSeverity{mode: mode, keywords: make(map[string]string)}
+	for _, k := range slices.Sorted(maps.Keys(overrides)) {
+		severity := overrides[k]
+		if !slices.Contains(validationModes, severity) {
+			return nil, fmt.Errorf("severity of %q is %q, expected one of %s", k, severity, strings.Join(validationModes, ", "))
+		}
+		if !strings.HasPrefix(k, "/") {
+			s.keywords[k] = severity
+			continue
+		}
+		segments, err := parsePointer(k)
+		if err != nil {
+			return nil, fmt.Errorf("severity override %q: %w", k, err)
+		}
+		s.paths = append(s.paths, severityPath{segments: segments, severity: severity})
+	}
+	return s, nil
+}
+
+// disabled reports whether every violation is ignored, so items need not be
+// validated at all.
+func (s *validationSeverity) disabled() bool {
+	if s.mode != validationModeOff {
+		return false
+	}
+	for _, severity := range s.keywords {
+		if severity != validationModeOff {
+			return false
+		}
+	}
+	for _, p := range s.paths {
+		if p.severity != validationModeOff {
+			return false
+		}
+	}
+	return true
+}
+
+// of returns how a violation is reported: validationModeError,
+// validationModeWarn or validationModeOff.
+func (s *validationSeverity) of(v specViolation) string {
+	var match *severityPath
+	for i, p := range s.paths {
+		if p.covers(v.Pointer) && (match == nil || len(p.segments) > len(match.segments)) {
+			match = &s.paths[i]
+		}
+	}
+	if match != nil {
+		return match.severity
+	}
+	if severity, ok := s.keywords[v.Keyword]; ok {
+		return severity
+	}
+	return s.mode
+}
+
+// covers reports whether the value at the given path is, or is within, a
+// value matching the pattern.
+func (p severityPath) covers(segments []string) bool {
+	if len(p.segments) > len(segments) {
+		return false
+	}
+	for i, s := range p.segments {
+		if s != "*" && s != segments[i] {
+			return false
+		}
+	}
+	return true
+}
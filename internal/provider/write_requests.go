@@ -0,0 +1,172 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	awstypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	// batchWriteItemLimit is the maximum number of requests in one
+	// BatchWriteItem call.
+	batchWriteItemLimit = 25
+	// transactWriteItemsLimit is the maximum number of actions in one
+	// TransactWriteItems call.
+	transactWriteItemsLimit = 100
+)
+
+// WriteRequestsModel describes the arguments and attributes shared by the data
+// sources that render converted items as write request payloads.
+type WriteRequestsModel struct {
+	TableName            types.String         `tfsdk:"table_name"`
+	ConditionExpression  types.String         `tfsdk:"condition_expression"`
+	BatchWriteRequests   types.List           `tfsdk:"batch_write_requests"`
+	TransactWriteRequest jsontypes.Normalized `tfsdk:"transact_write_request"`
+}
+
+// writeRequestsAttributes returns the schema attributes of WriteRequestsModel.
+func writeRequestsAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"table_name": schema.StringAttribute{
+			MarkdownDescription: "Name of the DynamoDB table the rendered payloads target. They are null unless it is set.",
+			Optional:            true,
+		},
+		"condition_expression": schema.StringAttribute{
+			MarkdownDescription: "Condition expression added to each `Put` action of `transact_write_request`, e.g. `attribute_not_exists(pk)`.",
+			Optional:            true,
+		},
+		"batch_write_requests": schema.ListAttribute{
+			MarkdownDescription: fmt.Sprintf("BatchWriteItem request documents (`RequestItems`) putting the items into `table_name`, chunked into groups of %d as the API requires.", batchWriteItemLimit),
+			Computed:            true,
+			ElementType:         jsontypes.NormalizedType{},
+		},
+		"transact_write_request": schema.StringAttribute{
+			MarkdownDescription: fmt.Sprintf("TransactWriteItems request document (`TransactItems`) putting the items into `table_name`. Null when there are no items, or more than %d items as a transaction cannot be split.", transactWriteItemsLimit),
+			Computed:            true,
+			CustomType:          jsontypes.NormalizedType{},
+		},
+	}
+}
+
+// setWriteRequests renders the write request attributes for the converted
+// items. They are null unless table_name is set.
+func (m *WriteRequestsModel) setWriteRequests(ctx context.Context, items []map[string]awstypes.AttributeValue) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	m.BatchWriteRequests = types.ListNull(jsontypes.NormalizedType{})
+	m.TransactWriteRequest = jsontypes.NewNormalizedNull()
+
+	if m.TableName.ValueString() == "" {
+		if m.ConditionExpression.ValueString() != "" {
+			diags.AddAttributeError(
+				path.Root("table_name"),
+				"Missing Table Name",
+				"A condition_expression only applies to write requests, which require table_name to be set.",
+			)
+		}
+		return diags
+	}
+
+	batches, err := batchWriteRequests(m.TableName.ValueString(), items)
+	if err != nil {
+		diags.AddError(
+			"Write Request Serialization Failed",
+			fmt.Sprintf("The provider received an unexpected error while attempting to render the BatchWriteItem requests.\n\nError: %s", err),
+		)
+		return diags
+	}
+	batchValues := make([]jsontypes.Normalized, 0, len(batches))
+	for _, b := range batches {
+		batchValues = append(batchValues, jsontypes.NewNormalizedValue(b))
+	}
+	m.BatchWriteRequests, diags = types.ListValueFrom(ctx, jsontypes.NormalizedType{}, batchValues)
+
+	// A transaction needs at least one action.
+	if len(items) == 0 {
+		return diags
+	}
+
+	if len(items) > transactWriteItemsLimit {
+		diags.AddAttributeWarning(
+			path.Root("transact_write_request"),
+			"Too Many Items For A Transaction",
+			fmt.Sprintf("TransactWriteItems accepts at most %d actions, got %d items. transact_write_request is left null.", transactWriteItemsLimit, len(items)),
+		)
+		return diags
+	}
+
+	transact, err := transactWriteRequest(m.TableName.ValueString(), m.ConditionExpression.ValueString(), items)
+	if err != nil {
+		diags.AddError(
+			"Write Request Serialization Failed",
+			fmt.Sprintf("The provider received an unexpected error while attempting to render the TransactWriteItems request.\n\nError: %s", err),
+		)
+		return diags
+	}
+	m.TransactWriteRequest = jsontypes.NewNormalizedValue(transact)
+	return diags
+}
+
+// batchWriteRequests renders items as BatchWriteItem request documents of
+// PutRequest entries for the given table, chunked to the API limit.
+func batchWriteRequests(tableName string, items []map[string]awstypes.AttributeValue) ([]string, error) {
+	requests := make([]string, 0, (len(items)+batchWriteItemLimit-1)/batchWriteItemLimit)
+	for chunk := range slices.Chunk(items, batchWriteItemLimit) {
+		writes := make([]interface{}, 0, len(chunk))
+		for _, item := range chunk {
+			raw, err := SerializeAttributeMap(item)
+			if err != nil {
+				return nil, err
+			}
+			writes = append(writes, map[string]interface{}{
+				"PutRequest": map[string]interface{}{
+					"Item": json.RawMessage(raw),
+				},
+			})
+		}
+
+		request, err := json.Marshal(map[string]interface{}{
+			"RequestItems": map[string]interface{}{
+				tableName: writes,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, string(request))
+	}
+	return requests, nil
+}
+
+// transactWriteRequest renders items as a TransactWriteItems request document
+// of Put actions for the given table, each with the optional condition.
+func transactWriteRequest(tableName, conditionExpression string, items []map[string]awstypes.AttributeValue) (string, error) {
+	actions := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		raw, err := SerializeAttributeMap(item)
+		if err != nil {
+			return "", err
+		}
+		put := map[string]interface{}{
+			"TableName": tableName,
+			"Item":      json.RawMessage(raw),
+		}
+		if conditionExpression != "" {
+			put["ConditionExpression"] = conditionExpression
+		}
+		actions = append(actions, map[string]interface{}{"Put": put})
+	}
+
+	request, err := json.Marshal(map[string]interface{}{
+		"TransactItems": actions,
+	})
+	return string(request), err
+}